@@ -0,0 +1,54 @@
+package ethdefaults
+
+var computeUnitCosts = map[string]float64{
+	"BalanceAt":               19,
+	"BlockByHash":             21,
+	"BlockByNumber":           16,
+	"BlockNumber":             10,
+	"CallContract":            26,
+	"CallContractAtHash":      26,
+	"ChainID":                 0,
+	"Close":                   0,
+	"CodeAt":                  19,
+	"EstimateGas":             87,
+	"FeeHistory":              10,
+	"FilterLogs":              75,
+	"HeaderByHash":            21,
+	"HeaderByNumber":          16,
+	"NetworkID":               0,
+	"NonceAt":                 26,
+	"PeerCount":               0,
+	"PendingBalanceAt":        19,
+	"PendingCallContract":     26,
+	"PendingCodeAt":           19,
+	"PendingNonceAt":          26,
+	"PendingStorageAt":        17,
+	"PendingTransactionCount": 20,
+	"SendTransaction":         250,
+	"StorageAt":               17,
+	"SubscribeFilterLogs":     10,
+	"SubscribeNewHead":        10,
+	"SuggestGasPrice":         19,
+	"SuggestGasTipCap":        10,
+	"SyncProgress":            0,
+	"TransactionByHash":       17,
+	"TransactionCount":        20,
+	"TransactionInBlock":      15,
+	"TransactionReceipt":      15,
+}
+
+// ComputeUnitCosts returns a new map of approximate compute unit costs of
+// each ethhelpers.Client method, based on the pricing of common RPC
+// providers.
+//
+// Providers differ and change their pricing, verify the costs against the
+// provider used.
+func ComputeUnitCosts() map[string]float64 {
+	costs := make(map[string]float64, len(computeUnitCosts))
+
+	for method, cost := range computeUnitCosts {
+		costs[method] = cost
+	}
+
+	return costs
+}
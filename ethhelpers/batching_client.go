@@ -0,0 +1,409 @@
+package ethhelpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	DefaultBatchWindow  = 5 * time.Millisecond
+	DefaultBatchMaxSize = 100
+)
+
+type BatchingClientOptions struct {
+	// Window is the time calls are gathered before the batch is sent,
+	// starting from the first call in the batch.
+	Window time.Duration
+
+	// MaxSize is the maximum number of calls in a batch, the batch is sent
+	// immediately when it is full.
+	MaxSize int
+}
+
+type rpcBatch struct {
+	elems   []rpc.BatchElem
+	done    chan struct{}
+	err     error
+	timer   *time.Timer
+	waiters int
+	cancel  func()
+
+	// deadline is the latest deadline of the callers' contexts, unless any
+	// caller has no deadline.
+	deadline   time.Time
+	noDeadline bool
+}
+
+type batchingClient struct {
+	*ethclient.Client
+
+	rpcClient *rpc.Client
+	opts      BatchingClientOptions
+
+	mu      sync.Mutex
+	current *rpcBatch
+}
+
+// NewBatchingClient creates a client that gathers concurrent calls and sends
+// them as a single JSON-RPC batch with rpc.Client.BatchCallContext.
+//
+// A batch is sent when Window has passed since the first call was added, or
+// when it reaches MaxSize calls. Each call returns its own result or error.
+//
+// A caller whose context is done stops waiting and returns the context error,
+// the batch is canceled only if all its callers have stopped waiting. The
+// batch uses the latest deadline of the callers' contexts, if all have a
+// deadline.
+//
+// Block, subscription and send calls, and calls that need more than one
+// request, are passed directly to an ethclient.Client.
+func NewBatchingClient(rpcClient *rpc.Client, opts BatchingClientOptions) (Client, error) {
+	if rpcClient == nil {
+		return nil, fmt.Errorf("rpcClient must not be nil")
+	}
+	if opts.Window < 0 {
+		return nil, fmt.Errorf("opts.Window must not be negative")
+	}
+	if opts.MaxSize < 0 {
+		return nil, fmt.Errorf("opts.MaxSize must not be negative")
+	}
+
+	if opts.Window == 0 {
+		opts.Window = DefaultBatchWindow
+	}
+	if opts.MaxSize == 0 {
+		opts.MaxSize = DefaultBatchMaxSize
+	}
+
+	return &batchingClient{
+		Client:    ethclient.NewClient(rpcClient),
+		rpcClient: rpcClient,
+		opts:      opts,
+	}, nil
+}
+
+// call adds the call to the current batch and waits for the result, which is
+// decoded into result the same way as rpc.Client.CallContext.
+func (c *batchingClient) call(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	raw := new(json.RawMessage)
+
+	b, idx := c.add(ctx, rpc.BatchElem{
+		Method: method,
+		Args:   args,
+		Result: raw,
+	})
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		c.leave(b)
+		return ctx.Err()
+	}
+
+	switch {
+	case b.err != nil:
+		return b.err
+	case b.elems[idx].Error != nil:
+		return b.elems[idx].Error
+	case result == nil:
+		return nil
+	default:
+		return json.Unmarshal(*raw, result)
+	}
+}
+
+func (c *batchingClient) add(ctx context.Context, elem rpc.BatchElem) (*rpcBatch, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b := c.current
+
+	if b == nil {
+		b = &rpcBatch{
+			done: make(chan struct{}),
+		}
+		b.timer = time.AfterFunc(c.opts.Window, func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			if c.current == b {
+				c.send(b)
+			}
+		})
+
+		c.current = b
+	}
+
+	if deadline, ok := ctx.Deadline(); !ok {
+		b.noDeadline = true
+	} else if deadline.After(b.deadline) {
+		b.deadline = deadline
+	}
+
+	b.elems = append(b.elems, elem)
+	b.waiters++
+
+	if len(b.elems) >= c.opts.MaxSize {
+		b.timer.Stop()
+		c.send(b)
+	}
+
+	return b, len(b.elems) - 1
+}
+
+// send starts sending the batch, must be called with the lock held.
+func (c *batchingClient) send(b *rpcBatch) {
+	c.current = nil
+
+	var ctx context.Context
+	var cancel func()
+
+	if b.noDeadline {
+		ctx, cancel = context.WithCancel(context.Background())
+	} else {
+		ctx, cancel = context.WithDeadline(context.Background(), b.deadline)
+	}
+
+	b.cancel = cancel
+
+	if b.waiters == 0 {
+		cancel()
+	}
+
+	go func() {
+		defer cancel()
+
+		b.err = c.rpcClient.BatchCallContext(ctx, b.elems)
+		close(b.done)
+	}()
+}
+
+func (c *batchingClient) leave(b *rpcBatch) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b.waiters--
+
+	if b.waiters != 0 {
+		return
+	}
+
+	// Drop the batch if it has not been sent yet, as no one is waiting for it.
+	if c.current == b {
+		b.timer.Stop()
+		c.current = nil
+		return
+	}
+
+	b.cancel()
+}
+
+func (c *batchingClient) ChainID(ctx context.Context) (*big.Int, error) {
+	var result hexutil.Big
+	err := c.call(ctx, &result, "eth_chainId")
+	if err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), err
+}
+
+func (c *batchingClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var result hexutil.Uint64
+	err := c.call(ctx, &result, "eth_blockNumber")
+	return uint64(result), err
+}
+
+func (c *batchingClient) PeerCount(ctx context.Context) (uint64, error) {
+	var result hexutil.Uint64
+	err := c.call(ctx, &result, "net_peerCount")
+	return uint64(result), err
+}
+
+func (c *batchingClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	var head *types.Header
+	err := c.call(ctx, &head, "eth_getBlockByHash", hash, false)
+	if err == nil && head == nil {
+		err = ethereum.NotFound
+	}
+	return head, err
+}
+
+func (c *batchingClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var head *types.Header
+	err := c.call(ctx, &head, "eth_getBlockByNumber", toBlockNumArg(number), false)
+	if err == nil && head == nil {
+		err = ethereum.NotFound
+	}
+	return head, err
+}
+
+func (c *batchingClient) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	var json *rpcTransaction
+	err = c.call(ctx, &json, "eth_getTransactionByHash", hash)
+	if err != nil {
+		return nil, false, err
+	} else if json == nil {
+		return nil, false, ethereum.NotFound
+	} else if _, r, _ := json.tx.RawSignatureValues(); r == nil {
+		return nil, false, fmt.Errorf("server returned transaction without signature")
+	}
+	return json.tx, json.BlockNumber == nil, nil
+}
+
+func (c *batchingClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	var num hexutil.Uint
+	err := c.call(ctx, &num, "eth_getBlockTransactionCountByHash", blockHash)
+	return uint(num), err
+}
+
+func (c *batchingClient) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	var json *rpcTransaction
+	err := c.call(ctx, &json, "eth_getTransactionByBlockHashAndIndex", blockHash, hexutil.Uint64(index))
+	if err != nil {
+		return nil, err
+	}
+	if json == nil {
+		return nil, ethereum.NotFound
+	} else if _, r, _ := json.tx.RawSignatureValues(); r == nil {
+		return nil, fmt.Errorf("server returned transaction without signature")
+	}
+	return json.tx, err
+}
+
+func (c *batchingClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var r *types.Receipt
+	err := c.call(ctx, &r, "eth_getTransactionReceipt", txHash)
+	if err == nil {
+		if r == nil {
+			return nil, ethereum.NotFound
+		}
+	}
+	return r, err
+}
+
+func (c *batchingClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var result hexutil.Big
+	err := c.call(ctx, &result, "eth_getBalance", account, toBlockNumArg(blockNumber))
+	return (*big.Int)(&result), err
+}
+
+func (c *batchingClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	var result hexutil.Bytes
+	err := c.call(ctx, &result, "eth_getStorageAt", account, key, toBlockNumArg(blockNumber))
+	return result, err
+}
+
+func (c *batchingClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var result hexutil.Bytes
+	err := c.call(ctx, &result, "eth_getCode", account, toBlockNumArg(blockNumber))
+	return result, err
+}
+
+func (c *batchingClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var result hexutil.Uint64
+	err := c.call(ctx, &result, "eth_getTransactionCount", account, toBlockNumArg(blockNumber))
+	return uint64(result), err
+}
+
+func (c *batchingClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var result []types.Log
+	arg, err := toFilterArg(q)
+	if err != nil {
+		return nil, err
+	}
+	err = c.call(ctx, &result, "eth_getLogs", arg)
+	return result, err
+}
+
+func (c *batchingClient) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	var result hexutil.Big
+	err := c.call(ctx, &result, "eth_getBalance", account, "pending")
+	return (*big.Int)(&result), err
+}
+
+func (c *batchingClient) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	var result hexutil.Bytes
+	err := c.call(ctx, &result, "eth_getStorageAt", account, key, "pending")
+	return result, err
+}
+
+func (c *batchingClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var result hexutil.Bytes
+	err := c.call(ctx, &result, "eth_getCode", account, "pending")
+	return result, err
+}
+
+func (c *batchingClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var result hexutil.Uint64
+	err := c.call(ctx, &result, "eth_getTransactionCount", account, "pending")
+	return uint64(result), err
+}
+
+func (c *batchingClient) PendingTransactionCount(ctx context.Context) (uint, error) {
+	var num hexutil.Uint
+	err := c.call(ctx, &num, "eth_getBlockTransactionCountByNumber", "pending")
+	return uint(num), err
+}
+
+func (c *batchingClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var hex hexutil.Bytes
+	err := c.call(ctx, &hex, "eth_call", toCallArg(msg), toBlockNumArg(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	return hex, nil
+}
+
+func (c *batchingClient) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	var hex hexutil.Bytes
+	err := c.call(ctx, &hex, "eth_call", toCallArg(msg), rpc.BlockNumberOrHashWithHash(blockHash, false))
+	if err != nil {
+		return nil, err
+	}
+	return hex, nil
+}
+
+func (c *batchingClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	var hex hexutil.Bytes
+	err := c.call(ctx, &hex, "eth_call", toCallArg(msg), "pending")
+	if err != nil {
+		return nil, err
+	}
+	return hex, nil
+}
+
+func (c *batchingClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var hex hexutil.Big
+	if err := c.call(ctx, &hex, "eth_gasPrice"); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&hex), nil
+}
+
+func (c *batchingClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var hex hexutil.Big
+	if err := c.call(ctx, &hex, "eth_maxPriorityFeePerGas"); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&hex), nil
+}
+
+func (c *batchingClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var hex hexutil.Uint64
+	err := c.call(ctx, &hex, "eth_estimateGas", toCallArg(msg))
+	if err != nil {
+		return 0, err
+	}
+	return uint64(hex), nil
+}
@@ -0,0 +1,195 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testBatchKnownHash  = common.HexToHash("0x01")
+	testBatchFailedHash = common.HexToHash("0x02")
+)
+
+type testBatchService struct{}
+
+func (s *testBatchService) BlockNumber() hexutil.Uint64 {
+	return 123
+}
+
+func (s *testBatchService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1))
+}
+
+func (s *testBatchService) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	switch hash {
+	case testBatchKnownHash:
+		return &types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			TxHash:      hash,
+			BlockNumber: big.NewInt(100),
+			Logs:        []*types.Log{},
+		}, nil
+	case testBatchFailedHash:
+		return nil, fmt.Errorf("receipt failed")
+	default:
+		return nil, nil
+	}
+}
+
+func TestBatchingClient(t *testing.T) {
+	type testArgs struct {
+		ctx      context.Context
+		client   ethhelpers.Client
+		requests func() int64
+	}
+
+	parallel := func(fns ...func()) {
+		var wg sync.WaitGroup
+
+		for _, fn := range fns {
+			wg.Add(1)
+
+			go func(fn func()) {
+				defer wg.Done()
+				fn()
+			}(fn)
+		}
+
+		wg.Wait()
+	}
+
+	tests := []struct {
+		name string
+		opts ethhelpers.BatchingClientOptions
+		fn   func(*testing.T, testArgs)
+	}{
+		{
+			name: "concurrent calls are sent in one batch",
+			opts: ethhelpers.BatchingClientOptions{Window: 50 * time.Millisecond},
+			fn: func(t *testing.T, args testArgs) {
+				parallel(
+					func() {
+						blockNumber, err := args.client.BlockNumber(args.ctx)
+						assert.NoError(t, err)
+						assert.Equal(t, uint64(123), blockNumber)
+					},
+					func() {
+						chainID, err := args.client.ChainID(args.ctx)
+						assert.NoError(t, err)
+						assert.Equal(t, big.NewInt(1), chainID)
+					},
+					func() {
+						receipt, err := args.client.TransactionReceipt(args.ctx, testBatchKnownHash)
+						if assert.NoError(t, err) {
+							assert.Equal(t, testBatchKnownHash, receipt.TxHash)
+							assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+						}
+					},
+				)
+
+				assert.Equal(t, int64(1), args.requests())
+			},
+		}, {
+			name: "errors are returned to the right caller",
+			opts: ethhelpers.BatchingClientOptions{Window: 50 * time.Millisecond},
+			fn: func(t *testing.T, args testArgs) {
+				parallel(
+					func() {
+						_, err := args.client.TransactionReceipt(args.ctx, common.HexToHash("0x03"))
+						assert.ErrorIs(t, err, ethereum.NotFound)
+					},
+					func() {
+						_, err := args.client.TransactionReceipt(args.ctx, testBatchFailedHash)
+						assert.EqualError(t, err, "receipt failed")
+					},
+					func() {
+						_, err := args.client.TransactionReceipt(args.ctx, testBatchKnownHash)
+						assert.NoError(t, err)
+					},
+				)
+
+				assert.Equal(t, int64(1), args.requests())
+			},
+		}, {
+			name: "full batches are sent immediately",
+			opts: ethhelpers.BatchingClientOptions{Window: time.Hour, MaxSize: 2},
+			fn: func(t *testing.T, args testArgs) {
+				call := func() {
+					blockNumber, err := args.client.BlockNumber(args.ctx)
+					assert.NoError(t, err)
+					assert.Equal(t, uint64(123), blockNumber)
+				}
+
+				parallel(call, call, call, call)
+
+				assert.Equal(t, int64(2), args.requests())
+			},
+		}, {
+			name: "canceled caller stops waiting",
+			opts: ethhelpers.BatchingClientOptions{Window: time.Hour},
+			fn: func(t *testing.T, args testArgs) {
+				ctx, cancel := context.WithTimeout(args.ctx, 10*time.Millisecond)
+				defer cancel()
+
+				_, err := args.client.BlockNumber(ctx)
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+				assert.Equal(t, int64(0), args.requests())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := rpc.NewServer()
+			defer server.Stop()
+
+			if err := server.RegisterName("eth", &testBatchService{}); err != nil {
+				t.Fatal(err)
+			}
+
+			var requests int64
+
+			httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt64(&requests, 1)
+				server.ServeHTTP(w, r)
+			}))
+			defer httpServer.Close()
+
+			rpcClient, err := rpc.DialHTTP(httpServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client, err := ethhelpers.NewBatchingClient(rpcClient, test.opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer client.Close()
+
+			test.fn(t, testArgs{
+				context.Background(),
+				client,
+				func() int64 { return atomic.LoadInt64(&requests) },
+			})
+		})
+	}
+}
@@ -0,0 +1,398 @@
+package ethhelpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	DefaultCacheSize                = 4096
+	DefaultCacheConfirmationDepth   = 12
+	DefaultCacheFinalizedDepth      = 64
+	DefaultCacheHeadRefreshInterval = 5 * time.Second
+)
+
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// CachingClient is a Client that caches results that cannot change.
+type CachingClient interface {
+	Client
+
+	// CacheStats returns the number of cache hits and misses of cacheable
+	// calls.
+	CacheStats() CacheStats
+}
+
+type CachingClientOptions struct {
+	// Size is the maximum number of cached results.
+	Size int
+
+	// ConfirmationDepth is the number of blocks a transaction receipt must be
+	// below the latest block before it is cached, or
+	// DefaultCacheConfirmationDepth if nil.
+	ConfirmationDepth *uint64
+
+	// FinalizedDepth is the number of blocks a block number must be below the
+	// latest block before CodeAt, StorageAt and CallContract results at that
+	// block are cached, or DefaultCacheFinalizedDepth if nil.
+	FinalizedDepth *uint64
+
+	// HeadRefreshInterval is the minimum time between BlockNumber calls made
+	// to check if a result is deep enough to be cached.
+	HeadRefreshInterval time.Duration
+
+	// Now returns the current time, or time.Now if nil.
+	Now func() time.Time
+}
+
+type cachingClient struct {
+	Client
+
+	cache  *lru.Cache
+	hits   uint64
+	misses uint64
+
+	mu          sync.Mutex
+	head        uint64
+	headUpdated time.Time
+
+	opts              CachingClientOptions
+	confirmationDepth uint64
+	finalizedDepth    uint64
+}
+
+type cacheKey struct {
+	method string
+	key    string
+}
+
+// NewCachingClient creates a client that caches results of calls that can
+// never change, in a size-bounded LRU cache.
+//
+// Results are cached for BlockByHash, HeaderByHash, TransactionCount,
+// TransactionInBlock and TransactionByHash of mined transactions. Transaction
+// receipts are cached once they are ConfirmationDepth blocks deep, and
+// CodeAt, StorageAt and CallContract results at block numbers that are
+// FinalizedDepth blocks deep.
+//
+// Errors are never cached. Cached results are copied so callers may modify
+// them, except transactions which are immutable.
+func NewCachingClient(client Client, opts CachingClientOptions) (CachingClient, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client must not be nil")
+	}
+	if opts.Size < 0 {
+		return nil, fmt.Errorf("opts.Size must not be negative")
+	}
+	if opts.HeadRefreshInterval < 0 {
+		return nil, fmt.Errorf("opts.HeadRefreshInterval must not be negative")
+	}
+
+	if opts.Size == 0 {
+		opts.Size = DefaultCacheSize
+	}
+	if opts.HeadRefreshInterval == 0 {
+		opts.HeadRefreshInterval = DefaultCacheHeadRefreshInterval
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	cache, err := lru.New(opts.Size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache: %v", err)
+	}
+
+	c := &cachingClient{
+		Client:            client,
+		cache:             cache,
+		opts:              opts,
+		confirmationDepth: DefaultCacheConfirmationDepth,
+		finalizedDepth:    DefaultCacheFinalizedDepth,
+	}
+
+	if opts.ConfirmationDepth != nil {
+		c.confirmationDepth = *opts.ConfirmationDepth
+	}
+	if opts.FinalizedDepth != nil {
+		c.finalizedDepth = *opts.FinalizedDepth
+	}
+
+	return c, nil
+}
+
+func (c *cachingClient) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadUint64(&c.hits),
+		Misses: atomic.LoadUint64(&c.misses),
+	}
+}
+
+func (c *cachingClient) get(key cacheKey) (interface{}, bool) {
+	value, ok := c.cache.Get(key)
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+
+	atomic.AddUint64(&c.hits, 1)
+	return value, true
+}
+
+func (c *cachingClient) updateHead(blockNumber uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if blockNumber > c.head {
+		c.head = blockNumber
+	}
+
+	c.headUpdated = c.opts.Now()
+}
+
+// isDeep returns true if the block number is at least depth blocks below the
+// latest block, refreshing the latest block number if it is stale.
+//
+// Failing to get the latest block number is not an error, the result is
+// simply not cached.
+func (c *cachingClient) isDeep(ctx context.Context, blockNumber uint64, depth uint64) bool {
+	c.mu.Lock()
+	head, headUpdated := c.head, c.headUpdated
+	c.mu.Unlock()
+
+	if blockNumber+depth <= head {
+		return true
+	}
+	if c.opts.Now().Sub(headUpdated) < c.opts.HeadRefreshInterval {
+		return false
+	}
+
+	head, err := c.Client.BlockNumber(ctx)
+	if err != nil {
+		return false
+	}
+
+	c.updateHead(head)
+
+	return blockNumber+depth <= head
+}
+
+func (c *cachingClient) isFinalized(ctx context.Context, blockNumber *big.Int) bool {
+	if blockNumber == nil || blockNumber.Sign() < 0 || !blockNumber.IsUint64() {
+		return false
+	}
+
+	return c.isDeep(ctx, blockNumber.Uint64(), c.finalizedDepth)
+}
+
+func (c *cachingClient) BlockNumber(ctx context.Context) (uint64, error) {
+	blockNumber, err := c.Client.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	c.updateHead(blockNumber)
+
+	return blockNumber, nil
+}
+
+func (c *cachingClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	key := cacheKey{"BlockByHash", hash.Hex()}
+
+	if value, ok := c.get(key); ok {
+		return copyBlock(value.(*types.Block)), nil
+	}
+
+	block, err := c.Client.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if block != nil {
+		c.cache.Add(key, copyBlock(block))
+	}
+
+	return block, nil
+}
+
+func (c *cachingClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	key := cacheKey{"HeaderByHash", hash.Hex()}
+
+	if value, ok := c.get(key); ok {
+		return types.CopyHeader(value.(*types.Header)), nil
+	}
+
+	header, err := c.Client.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if header != nil {
+		c.cache.Add(key, types.CopyHeader(header))
+	}
+
+	return header, nil
+}
+
+func (c *cachingClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	key := cacheKey{"TransactionCount", blockHash.Hex()}
+
+	if value, ok := c.get(key); ok {
+		return value.(uint), nil
+	}
+
+	count, err := c.Client.TransactionCount(ctx, blockHash)
+	if err != nil {
+		return 0, err
+	}
+
+	c.cache.Add(key, count)
+
+	return count, nil
+}
+
+func (c *cachingClient) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	key := cacheKey{"TransactionInBlock", fmt.Sprintf("%s:%d", blockHash.Hex(), index)}
+
+	if value, ok := c.get(key); ok {
+		return value.(*types.Transaction), nil
+	}
+
+	tx, err := c.Client.TransactionInBlock(ctx, blockHash, index)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.Add(key, tx)
+
+	return tx, nil
+}
+
+func (c *cachingClient) TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	key := cacheKey{"TransactionByHash", txHash.Hex()}
+
+	if value, ok := c.get(key); ok {
+		return value.(*types.Transaction), false, nil
+	}
+
+	tx, isPending, err := c.Client.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !isPending {
+		c.cache.Add(key, tx)
+	}
+
+	return tx, isPending, nil
+}
+
+func (c *cachingClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	key := cacheKey{"TransactionReceipt", txHash.Hex()}
+
+	if value, ok := c.get(key); ok {
+		return copyReceipt(value.(*types.Receipt)), nil
+	}
+
+	receipt, err := c.Client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt == nil || receipt.BlockNumber == nil || !receipt.BlockNumber.IsUint64() {
+		return receipt, nil
+	}
+
+	if c.isDeep(ctx, receipt.BlockNumber.Uint64(), c.confirmationDepth) {
+		c.cache.Add(key, copyReceipt(receipt))
+	}
+
+	return receipt, nil
+}
+
+func (c *cachingClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.getBytesAt(ctx, "CodeAt", fmt.Sprintf("%s:%v", account.Hex(), blockNumber), blockNumber, func() ([]byte, error) {
+		return c.Client.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *cachingClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return c.getBytesAt(ctx, "StorageAt", fmt.Sprintf("%s:%s:%v", account.Hex(), key.Hex(), blockNumber), blockNumber, func() ([]byte, error) {
+		return c.Client.StorageAt(ctx, account, key, blockNumber)
+	})
+}
+
+func (c *cachingClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	msg, err := json.Marshal(call)
+	if err != nil {
+		return c.Client.CallContract(ctx, call, blockNumber)
+	}
+
+	return c.getBytesAt(ctx, "CallContract", fmt.Sprintf("%s:%v", msg, blockNumber), blockNumber, func() ([]byte, error) {
+		return c.Client.CallContract(ctx, call, blockNumber)
+	})
+}
+
+func (c *cachingClient) getBytesAt(ctx context.Context, method string, key string, blockNumber *big.Int, fn func() ([]byte, error)) ([]byte, error) {
+	if blockNumber == nil || blockNumber.Sign() < 0 {
+		return fn()
+	}
+
+	k := cacheKey{method, key}
+
+	if value, ok := c.get(k); ok {
+		return common.CopyBytes(value.([]byte)), nil
+	}
+
+	result, err := fn()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.isFinalized(ctx, blockNumber) {
+		c.cache.Add(k, common.CopyBytes(result))
+	}
+
+	return result, nil
+}
+
+// copyBlock returns a copy of the block, sharing only the transactions which
+// are immutable.
+func copyBlock(block *types.Block) *types.Block {
+	return block.WithBody(block.Transactions(), block.Uncles())
+}
+
+func copyReceipt(receipt *types.Receipt) *types.Receipt {
+	r := *receipt
+	r.PostState = common.CopyBytes(receipt.PostState)
+
+	if receipt.BlockNumber != nil {
+		r.BlockNumber = new(big.Int).Set(receipt.BlockNumber)
+	}
+
+	if receipt.Logs != nil {
+		r.Logs = make([]*types.Log, len(receipt.Logs))
+
+		for idx, log := range receipt.Logs {
+			l := *log
+			l.Topics = append([]common.Hash(nil), log.Topics...)
+			l.Data = common.CopyBytes(log.Data)
+			r.Logs[idx] = &l
+		}
+	}
+
+	return &r
+}
@@ -0,0 +1,244 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+func TestCachingClient(t *testing.T) {
+	type testArgs struct {
+		ctx     context.Context
+		client  ethhelpers.CachingClient
+		mock    *mock.Mock
+		advance func(time.Duration)
+	}
+
+	account := common.HexToAddress("0x1234")
+	hash := common.HexToHash("0x5678")
+	tx := types.NewTransaction(1, account, big.NewInt(1), 21000, big.NewInt(1), nil)
+
+	tests := []struct {
+		name          string
+		opts          ethhelpers.CachingClientOptions
+		fn            func(*testing.T, testArgs)
+		expectedStats ethhelpers.CacheStats
+	}{
+		{
+			name: "BlockByHash is cached and returns a copy",
+			fn: func(t *testing.T, args testArgs) {
+				block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}).WithBody([]*types.Transaction{tx}, nil)
+
+				args.mock.On("BlockByHash", args.ctx, hash).Return(block, nil).Once()
+
+				result, err := args.client.BlockByHash(args.ctx, hash)
+				assert.NoError(t, err)
+				assert.Same(t, block, result)
+
+				result, err = args.client.BlockByHash(args.ctx, hash)
+				assert.NoError(t, err)
+				assert.NotSame(t, block, result)
+				assert.Equal(t, block.Hash(), result.Hash())
+				assert.Equal(t, block.Transactions(), result.Transactions())
+			},
+			expectedStats: ethhelpers.CacheStats{Hits: 1, Misses: 1},
+		}, {
+			name: "HeaderByHash returns a copy",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("HeaderByHash", args.ctx, hash).Return(&types.Header{Number: big.NewInt(1)}, nil).Once()
+
+				header, err := args.client.HeaderByHash(args.ctx, hash)
+				assert.NoError(t, err)
+				header.Number.SetUint64(2)
+
+				header, err = args.client.HeaderByHash(args.ctx, hash)
+				assert.NoError(t, err)
+				assert.Equal(t, big.NewInt(1), header.Number)
+			},
+			expectedStats: ethhelpers.CacheStats{Hits: 1, Misses: 1},
+		}, {
+			name: "errors are not cached",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("TransactionCount", args.ctx, hash).Return(uint(0), ethereum.NotFound).Once()
+				args.mock.On("TransactionCount", args.ctx, hash).Return(uint(2), nil).Once()
+
+				_, err := args.client.TransactionCount(args.ctx, hash)
+				assert.ErrorIs(t, err, ethereum.NotFound)
+
+				for i := 0; i < 2; i++ {
+					count, err := args.client.TransactionCount(args.ctx, hash)
+					assert.NoError(t, err)
+					assert.Equal(t, uint(2), count)
+				}
+			},
+			expectedStats: ethhelpers.CacheStats{Hits: 1, Misses: 2},
+		}, {
+			name: "TransactionByHash is only cached when mined",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("TransactionByHash", args.ctx, tx.Hash()).Return(tx, true, nil).Once()
+				args.mock.On("TransactionByHash", args.ctx, tx.Hash()).Return(tx, false, nil).Once()
+
+				_, isPending, err := args.client.TransactionByHash(args.ctx, tx.Hash())
+				assert.NoError(t, err)
+				assert.True(t, isPending)
+
+				for i := 0; i < 2; i++ {
+					result, isPending, err := args.client.TransactionByHash(args.ctx, tx.Hash())
+					assert.NoError(t, err)
+					assert.False(t, isPending)
+					assert.Same(t, tx, result)
+				}
+			},
+			expectedStats: ethhelpers.CacheStats{Hits: 1, Misses: 2},
+		}, {
+			name: "TransactionReceipt is cached when confirmed",
+			opts: ethhelpers.CachingClientOptions{ConfirmationDepth: uint64Ptr(10), HeadRefreshInterval: time.Second},
+			fn: func(t *testing.T, args testArgs) {
+				receipt := &types.Receipt{
+					TxHash:      tx.Hash(),
+					BlockNumber: big.NewInt(100),
+					Logs:        []*types.Log{{Data: []byte{0x01}}},
+				}
+
+				args.mock.On("TransactionReceipt", args.ctx, tx.Hash()).Return(receipt, nil).Times(3)
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(105), nil).Once()
+
+				for i := 0; i < 2; i++ {
+					_, err := args.client.TransactionReceipt(args.ctx, tx.Hash())
+					assert.NoError(t, err)
+				}
+
+				args.advance(time.Second)
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(110), nil).Once()
+
+				for i := 0; i < 3; i++ {
+					result, err := args.client.TransactionReceipt(args.ctx, tx.Hash())
+					assert.NoError(t, err)
+					assert.Equal(t, tx.Hash(), result.TxHash)
+					assert.Equal(t, []byte{0x01}, result.Logs[0].Data)
+
+					result.Logs[0].Data[0] = 0x02
+				}
+			},
+			expectedStats: ethhelpers.CacheStats{Hits: 2, Misses: 3},
+		}, {
+			name: "TransactionReceipt is cached at zero confirmation depth",
+			opts: ethhelpers.CachingClientOptions{ConfirmationDepth: uint64Ptr(0)},
+			fn: func(t *testing.T, args testArgs) {
+				receipt := &types.Receipt{TxHash: tx.Hash(), BlockNumber: big.NewInt(100)}
+
+				args.mock.On("TransactionReceipt", args.ctx, tx.Hash()).Return(receipt, nil).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(100), nil).Once()
+
+				for i := 0; i < 2; i++ {
+					result, err := args.client.TransactionReceipt(args.ctx, tx.Hash())
+					assert.NoError(t, err)
+					assert.Equal(t, tx.Hash(), result.TxHash)
+				}
+			},
+			expectedStats: ethhelpers.CacheStats{Hits: 1, Misses: 1},
+		}, {
+			name: "nil TransactionReceipt is not cached",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("TransactionReceipt", args.ctx, tx.Hash()).Return(nil, nil).Twice()
+
+				for i := 0; i < 2; i++ {
+					result, err := args.client.TransactionReceipt(args.ctx, tx.Hash())
+					assert.NoError(t, err)
+					assert.Nil(t, result)
+				}
+			},
+			expectedStats: ethhelpers.CacheStats{Hits: 0, Misses: 2},
+		}, {
+			name: "CodeAt is cached when finalized",
+			opts: ethhelpers.CachingClientOptions{FinalizedDepth: uint64Ptr(10)},
+			fn: func(t *testing.T, args testArgs) {
+				code := []byte{0x60, 0x80}
+
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(110), nil).Once()
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(110), blockNumber)
+
+				args.mock.On("CodeAt", args.ctx, account, (*big.Int)(nil)).Return(code, nil).Twice()
+				args.mock.On("CodeAt", args.ctx, account, big.NewInt(101)).Return(code, nil).Twice()
+				args.mock.On("CodeAt", args.ctx, account, big.NewInt(100)).Return(code, nil).Once()
+
+				for _, number := range []*big.Int{nil, nil, big.NewInt(101), big.NewInt(101), big.NewInt(100), big.NewInt(100)} {
+					result, err := args.client.CodeAt(args.ctx, account, number)
+					assert.NoError(t, err)
+					assert.Equal(t, code, result)
+				}
+			},
+			expectedStats: ethhelpers.CacheStats{Hits: 1, Misses: 3},
+		}, {
+			name: "CallContract is cached when finalized",
+			opts: ethhelpers.CachingClientOptions{FinalizedDepth: uint64Ptr(10)},
+			fn: func(t *testing.T, args testArgs) {
+				call := ethereum.CallMsg{To: &account, Data: []byte{0x01}}
+				otherCall := ethereum.CallMsg{To: &account, Data: []byte{0x02}}
+
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(110), nil).Once()
+				args.mock.On("CallContract", args.ctx, call, big.NewInt(100)).Return([]byte{0x03}, nil).Once()
+				args.mock.On("CallContract", args.ctx, otherCall, big.NewInt(100)).Return([]byte{0x04}, nil).Once()
+
+				for i := 0; i < 2; i++ {
+					result, err := args.client.CallContract(args.ctx, call, big.NewInt(100))
+					assert.NoError(t, err)
+					assert.Equal(t, []byte{0x03}, result)
+				}
+
+				result, err := args.client.CallContract(args.ctx, otherCall, big.NewInt(100))
+				assert.NoError(t, err)
+				assert.Equal(t, []byte{0x04}, result)
+			},
+			expectedStats: ethhelpers.CacheStats{Hits: 1, Misses: 2},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			now := time.Unix(1000000, 0)
+
+			mockClient := ethtesting.NewClientWithMock()
+			mockClient.Test(t)
+
+			opts := test.opts
+			opts.Now = func() time.Time { return now }
+
+			client, err := ethhelpers.NewCachingClient(mockClient, opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			test.fn(t, testArgs{
+				ctx,
+				client,
+				mockClient.Mock(),
+				func(d time.Duration) { now = now.Add(d) },
+			})
+
+			assert.Equal(t, test.expectedStats, client.CacheStats())
+			mockClient.Mock().AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package ethhelpers
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// CheckpointStore saves the last block number processed by a subscription,
+// identified by a key, so that it can be resumed after a restart.
+type CheckpointStore interface {
+	// LoadCheckpoint returns the block number saved for key, or false if no
+	// block number has been saved.
+	LoadCheckpoint(ctx context.Context, key string) (uint64, bool, error)
+
+	// SaveCheckpoint saves the block number for key, replacing any previous
+	// block number.
+	SaveCheckpoint(ctx context.Context, key string, blockNumber uint64) error
+}
+
+type memoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]uint64
+}
+
+// NewMemoryCheckpointStore creates a CheckpointStore that keeps the
+// checkpoints in memory, which is useful for tests and for resuming
+// subscriptions within the same process.
+func NewMemoryCheckpointStore() CheckpointStore {
+	return &memoryCheckpointStore{
+		checkpoints: make(map[string]uint64),
+	}
+}
+
+func (s *memoryCheckpointStore) LoadCheckpoint(ctx context.Context, key string) (uint64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blockNumber, ok := s.checkpoints[key]
+	return blockNumber, ok, nil
+}
+
+func (s *memoryCheckpointStore) SaveCheckpoint(ctx context.Context, key string, blockNumber uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoints[key] = blockNumber
+	return nil
+}
+
+type fileCheckpointStore struct {
+	path string
+
+	mu          sync.Mutex
+	checkpoints map[string]uint64
+}
+
+// NewFileCheckpointStore creates a CheckpointStore that keeps the checkpoints
+// of all keys in a JSON file at path, which is created if it does not exist.
+//
+// The file is replaced atomically on each save, and must not be shared with
+// other stores.
+func NewFileCheckpointStore(path string) (CheckpointStore, error) {
+	if path == "" {
+		return nil, fmt.Errorf("path must not be empty")
+	}
+
+	s := &fileCheckpointStore{
+		path:        path,
+		checkpoints: make(map[string]uint64),
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s.checkpoints); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint file: %w", err)
+	}
+
+	return s, nil
+}
+
+func (s *fileCheckpointStore) LoadCheckpoint(ctx context.Context, key string) (uint64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blockNumber, ok := s.checkpoints[key]
+	return blockNumber, ok, nil
+}
+
+func (s *fileCheckpointStore) SaveCheckpoint(ctx context.Context, key string, blockNumber uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints := make(map[string]uint64, len(s.checkpoints)+1)
+	for k, v := range s.checkpoints {
+		checkpoints[k] = v
+	}
+	checkpoints[key] = blockNumber
+
+	data, err := json.Marshal(checkpoints)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint file: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+
+	s.checkpoints = checkpoints
+
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and
+// renames it to path, so a crash never leaves a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := f.Name()
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
+
+const levelDBCheckpointPrefix = "ethhelpers-checkpoint-"
+
+type levelDBCheckpointStore struct {
+	db *leveldb.DB
+}
+
+// NewLevelDBCheckpointStore creates a CheckpointStore that keeps the
+// checkpoints in a LevelDB database, with each key prefixed to avoid
+// collisions with other data in the database.
+//
+// The database is not closed by the store.
+func NewLevelDBCheckpointStore(db *leveldb.DB) (CheckpointStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db must not be nil")
+	}
+
+	return &levelDBCheckpointStore{
+		db: db,
+	}, nil
+}
+
+func (s *levelDBCheckpointStore) LoadCheckpoint(ctx context.Context, key string) (uint64, bool, error) {
+	value, err := s.db.Get([]byte(levelDBCheckpointPrefix+key), nil)
+	switch {
+	case errors.Is(err, leveldb.ErrNotFound):
+		return 0, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("failed to read checkpoint: %w", err)
+	case len(value) != 8:
+		return 0, false, fmt.Errorf("invalid checkpoint value of length %d", len(value))
+	}
+
+	return binary.BigEndian.Uint64(value), true, nil
+}
+
+func (s *levelDBCheckpointStore) SaveCheckpoint(ctx context.Context, key string, blockNumber uint64) error {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, blockNumber)
+
+	if err := s.db.Put([]byte(levelDBCheckpointPrefix+key), value, nil); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	return nil
+}
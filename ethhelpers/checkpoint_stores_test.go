@@ -0,0 +1,122 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestCheckpointStores(t *testing.T) {
+	tests := []struct {
+		name string
+		// open returns a store using dir and a function that closes it, with
+		// the saved checkpoints returned when opened again if persistent.
+		open       func(t *testing.T, dir string) (ethhelpers.CheckpointStore, func())
+		persistent bool
+	}{
+		{
+			name: "memory",
+			open: func(t *testing.T, dir string) (ethhelpers.CheckpointStore, func()) {
+				return ethhelpers.NewMemoryCheckpointStore(), func() {}
+			},
+		}, {
+			name: "file",
+			open: func(t *testing.T, dir string) (ethhelpers.CheckpointStore, func()) {
+				store, err := ethhelpers.NewFileCheckpointStore(filepath.Join(dir, "checkpoints.json"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return store, func() {}
+			},
+			persistent: true,
+		}, {
+			name: "leveldb",
+			open: func(t *testing.T, dir string) (ethhelpers.CheckpointStore, func()) {
+				db, err := leveldb.OpenFile(filepath.Join(dir, "leveldb"), nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				store, err := ethhelpers.NewLevelDBCheckpointStore(db)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return store, func() { assert.NoError(t, db.Close()) }
+			},
+			persistent: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			dir := t.TempDir()
+
+			store, closeStore := test.open(t, dir)
+
+			blockNumber, ok, err := store.LoadCheckpoint(ctx, "a")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+			assert.Equal(t, uint64(0), blockNumber)
+
+			assert.NoError(t, store.SaveCheckpoint(ctx, "a", 10))
+			assert.NoError(t, store.SaveCheckpoint(ctx, "b", 20))
+			assert.NoError(t, store.SaveCheckpoint(ctx, "a", 11))
+
+			blockNumber, ok, err = store.LoadCheckpoint(ctx, "a")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, uint64(11), blockNumber)
+
+			closeStore()
+
+			if !test.persistent {
+				return
+			}
+
+			store, closeStore = test.open(t, dir)
+			defer closeStore()
+
+			blockNumber, ok, err = store.LoadCheckpoint(ctx, "a")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, uint64(11), blockNumber)
+
+			blockNumber, ok, err = store.LoadCheckpoint(ctx, "b")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, uint64(20), blockNumber)
+		})
+	}
+}
+
+func TestNewFileCheckpointStore_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := ethhelpers.NewFileCheckpointStore(path)
+	assert.EqualError(t, err, "failed to decode checkpoint file: invalid character 'o' in literal null (expecting 'u')")
+	assert.Nil(t, store)
+
+	store, err = ethhelpers.NewFileCheckpointStore("")
+	assert.EqualError(t, err, "path must not be empty")
+	assert.Nil(t, store)
+}
+
+func TestNewLevelDBCheckpointStore_NilDB(t *testing.T) {
+	store, err := ethhelpers.NewLevelDBCheckpointStore(nil)
+	assert.EqualError(t, err, "db must not be nil")
+	assert.Nil(t, store)
+}
@@ -0,0 +1,77 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// callClientMethod calls the client method with the given name and arguments,
+// returning the results separately from the error.
+//
+// The call is made independently of the ClientCaller, whose results are owned
+// by the caller that may have stopped waiting.
+func callClientMethod(ctx context.Context, client Client, method string, args []interface{}) ([]interface{}, error) {
+	fn := reflect.ValueOf(client).MethodByName(method)
+	if !fn.IsValid() {
+		return nil, fmt.Errorf("unknown client method %s", method)
+	}
+
+	return callMethod(ctx, fn, args)
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// callMethod calls fn with the arguments, preceded by the context if fn takes
+// one, returning the results separately from the error.
+func callMethod(ctx context.Context, fn reflect.Value, args []interface{}) ([]interface{}, error) {
+	var in []reflect.Value
+
+	switch fn.Type().NumIn() {
+	case len(args) + 1:
+		in = append(in, reflect.ValueOf(ctx))
+	case len(args):
+	default:
+		return nil, fmt.Errorf("invalid number of arguments for method of type %s", fn.Type())
+	}
+
+	for _, arg := range args {
+		if arg == nil {
+			in = append(in, reflect.Zero(fn.Type().In(len(in))))
+		} else {
+			in = append(in, reflect.ValueOf(arg))
+		}
+	}
+
+	out := fn.Call(in)
+
+	var err error
+
+	if len(out) != 0 && fn.Type().Out(len(out)-1) == errorType {
+		err, _ = out[len(out)-1].Interface().(error)
+		out = out[:len(out)-1]
+	}
+
+	results := make([]interface{}, len(out))
+
+	for idx := range results {
+		results[idx] = out[idx].Interface()
+	}
+
+	return results, err
+}
+
+// detachedContext keeps the values of the parent context but is never
+// canceled.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
@@ -0,0 +1,76 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// ClientStackOptions selects the wrappers used by NewClientStack, a wrapper is
+// not used if its option is nil.
+type ClientStackOptions struct {
+	// Logger enables NewClientWithLogging, configured by Logging.
+	Logger  log.Logger
+	Logging LoggingOptions
+
+	// Retry enables NewClientWithRetry, retrying temporary errors with
+	// RetryIfTemporaryErrorWithOptions.
+	Retry *RetryOptions
+
+	// MetricsRegistry enables NewClientWithMetrics, with metric names
+	// prefixed by MetricsPrefix.
+	MetricsRegistry metrics.Registry
+	MetricsPrefix   string
+
+	// CircuitBreaker enables NewClientWithCircuitBreaker.
+	CircuitBreaker *CircuitBreakerOptions
+
+	// RateLimit enables NewClientWithRateLimit.
+	RateLimit *RateLimitOptions
+}
+
+// NewClientStack creates a client that wraps client with the wrappers enabled
+// in opts, in the following order from outermost to innermost:
+//
+//	Logging -> Retry -> Metrics -> CircuitBreaker -> RateLimit -> client
+//
+// Each logical call is logged once with the final result, while metrics,
+// the circuit breaker and the rate limit see every retried attempt. Calls
+// rejected by the open circuit breaker are not retried.
+func NewClientStack(client Client, opts ClientStackOptions) (Client, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client must not be nil")
+	}
+
+	var err error
+
+	if opts.RateLimit != nil {
+		if client, err = NewClientWithRateLimit(client, *opts.RateLimit); err != nil {
+			return nil, fmt.Errorf("invalid rate limit options: %w", err)
+		}
+	}
+	if opts.CircuitBreaker != nil {
+		if client, err = NewClientWithCircuitBreaker(client, *opts.CircuitBreaker); err != nil {
+			return nil, fmt.Errorf("invalid circuit breaker options: %w", err)
+		}
+	}
+	if opts.MetricsRegistry != nil {
+		client = NewClientWithMetrics(client, opts.MetricsRegistry, opts.MetricsPrefix)
+	}
+	if opts.Retry != nil {
+		if _, err := opts.Retry.withDefaults(); err != nil {
+			return nil, fmt.Errorf("invalid retry options: %w", err)
+		}
+
+		client = NewClientWithRetry(client, RetryIfTemporaryErrorWithOptions(func(ctx context.Context, err error) error {
+			return err
+		}, *opts.Retry))
+	}
+	if opts.Logger != nil {
+		client = NewClientWithLogging(client, opts.Logger, opts.Logging)
+	}
+
+	return client, nil
+}
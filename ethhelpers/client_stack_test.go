@@ -0,0 +1,118 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientStack(t *testing.T) {
+	enableMetrics(t)
+
+	ctx := context.Background()
+	registry := metrics.NewRegistry()
+
+	mockClient := ethtesting.NewClientWithMock()
+	mockClient.Test(t)
+	mockClient.Mock().On("BlockNumber", ctx).Return(uint64(0), syscall.ECONNRESET).Twice()
+	mockClient.Mock().On("BlockNumber", ctx).Return(uint64(123), nil).Once()
+
+	logger, records := newTestLogger()
+
+	client, err := ethhelpers.NewClientStack(mockClient, ethhelpers.ClientStackOptions{
+		Logger: logger,
+		Retry: &ethhelpers.RetryOptions{
+			Sleep: func(ctx context.Context, d time.Duration) error { return nil },
+		},
+		MetricsRegistry: registry,
+		MetricsPrefix:   "test",
+		CircuitBreaker:  &ethhelpers.CircuitBreakerOptions{},
+		RateLimit:       &ethhelpers.RateLimitOptions{Rate: 1000, Burst: 1000},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	n, err := client.BlockNumber(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(123), n)
+
+	// Logging is outside of retry, metrics is inside.
+	if assert.Len(t, *records, 1) {
+		assert.Equal(t, "123", logRecordValues((*records)[0])["result"])
+	}
+	if timer, ok := registry.Get("test/BlockNumber/duration").(metrics.Timer); assert.True(t, ok) {
+		assert.Equal(t, int64(3), timer.Count())
+	}
+	if counter, ok := registry.Get("test/BlockNumber/errors/temporary").(metrics.Counter); assert.True(t, ok) {
+		assert.Equal(t, int64(2), counter.Count())
+	}
+
+	mockClient.Mock().AssertExpectations(t)
+}
+
+func TestNewClientStack_CircuitOpenIsNotRetried(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := ethtesting.NewClientWithMock()
+	mockClient.Test(t)
+	mockClient.Mock().On("BlockNumber", ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+
+	client, err := ethhelpers.NewClientStack(mockClient, ethhelpers.ClientStackOptions{
+		Retry: &ethhelpers.RetryOptions{
+			Sleep: func(ctx context.Context, d time.Duration) error { return nil },
+		},
+		CircuitBreaker: &ethhelpers.CircuitBreakerOptions{FailureThreshold: 1},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = client.BlockNumber(ctx)
+	assert.ErrorIs(t, err, ethhelpers.ErrCircuitOpen)
+
+	mockClient.Mock().AssertExpectations(t)
+}
+
+func TestNewClientStack_InvalidOptions(t *testing.T) {
+	mockClient := ethtesting.NewClientWithMock()
+
+	tests := []struct {
+		name   string
+		client ethhelpers.Client
+		opts   ethhelpers.ClientStackOptions
+		err    string
+	}{
+		{
+			name: "nil client",
+			err:  "client must not be nil",
+		}, {
+			name:   "invalid retry options",
+			client: mockClient,
+			opts:   ethhelpers.ClientStackOptions{Retry: &ethhelpers.RetryOptions{MaxAttempts: -1}},
+			err:    "invalid retry options: opts.MaxAttempts must not be negative",
+		}, {
+			name:   "invalid circuit breaker options",
+			client: mockClient,
+			opts:   ethhelpers.ClientStackOptions{CircuitBreaker: &ethhelpers.CircuitBreakerOptions{MinRequests: -1}},
+			err:    "invalid circuit breaker options: opts.MinRequests must not be negative",
+		}, {
+			name:   "invalid rate limit options",
+			client: mockClient,
+			opts:   ethhelpers.ClientStackOptions{RateLimit: &ethhelpers.RateLimitOptions{}},
+			err:    "invalid rate limit options: opts.Rate must be positive",
+		},
+	}
+
+	for _, test := range tests {
+		client, err := ethhelpers.NewClientStack(test.client, test.opts)
+		assert.Nil(t, client, test.name)
+		assert.EqualError(t, err, test.err, test.name)
+	}
+}
@@ -0,0 +1,286 @@
+package ethhelpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultCircuitBreakerFailureThreshold = 5
+	DefaultCircuitBreakerMinRequests      = 10
+	DefaultCircuitBreakerInterval         = 1 * time.Minute
+	DefaultCircuitBreakerOpenTimeout      = 30 * time.Second
+	DefaultCircuitBreakerHalfOpenMaxCalls = 1
+)
+
+// ErrCircuitOpen is matched by errors returned when a call fails fast due to
+// an open circuit breaker.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned by calls rejected by an open circuit breaker.
+type CircuitOpenError struct {
+	Method string
+
+	// Until is the time the circuit breaker allows probe calls, or the zero
+	// value if the circuit breaker is already half-open.
+	Until time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open for %s", e.Method)
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit, or DefaultCircuitBreakerFailureThreshold if zero.
+	//
+	// Disabled if negative, leaving only FailureRatio to open the circuit.
+	FailureThreshold int
+
+	// FailureRatio opens the circuit when the ratio of failed calls within
+	// Interval reaches it, if at least MinRequests calls were made.
+	//
+	// Disabled if zero, must be in the range [0.0, 1.0]. FailureRatio must
+	// be set if FailureThreshold is disabled.
+	FailureRatio float64
+	MinRequests  int
+
+	// Interval is the period over which the failure ratio is counted.
+	Interval time.Duration
+
+	// OpenTimeout is the time the circuit stays open before allowing probe
+	// calls.
+	OpenTimeout time.Duration
+
+	// HalfOpenMaxCalls is the number of concurrent probe calls allowed while
+	// half-open. The circuit is closed after the same number of successful
+	// probe calls.
+	HalfOpenMaxCalls int
+
+	// IsFailure returns true if the error should be counted as a failure, or
+	// uses ClassifyError(err).IsTemporary() if nil.
+	//
+	// Canceled calls are never counted.
+	IsFailure func(err error) bool
+
+	// Now returns the current time, or time.Now if nil.
+	Now func() time.Time
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+type methodCircuit struct {
+	state circuitState
+
+	failures       int
+	windowStart    time.Time
+	windowCalls    int
+	windowFailures int
+
+	openedAt          time.Time
+	halfOpenCalls     int
+	halfOpenSuccesses int
+}
+
+type circuitBreaker struct {
+	mu       sync.Mutex
+	circuits map[string]*methodCircuit
+	opts     CircuitBreakerOptions
+}
+
+// NewClientWithCircuitBreaker creates a client that fails fast with a
+// *CircuitOpenError after repeated failures, tracking the state separately for
+// each method.
+//
+// When the circuit is open all calls to the method fail until OpenTimeout has
+// passed, after which a limited number of probe calls are let through. The
+// circuit is closed if the probe calls succeed, or reopened on failure.
+func NewClientWithCircuitBreaker(client Client, opts CircuitBreakerOptions) (Client, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client must not be nil")
+	}
+
+	switch {
+	case opts.FailureRatio < 0.0 || opts.FailureRatio > 1.0:
+		return nil, fmt.Errorf("opts.FailureRatio must be in the range [0.0, 1.0]")
+	case opts.FailureThreshold < 0 && opts.FailureRatio == 0:
+		return nil, fmt.Errorf("opts.FailureRatio must be set if opts.FailureThreshold is disabled")
+	case opts.MinRequests < 0:
+		return nil, fmt.Errorf("opts.MinRequests must not be negative")
+	case opts.Interval < 0:
+		return nil, fmt.Errorf("opts.Interval must not be negative")
+	case opts.OpenTimeout < 0:
+		return nil, fmt.Errorf("opts.OpenTimeout must not be negative")
+	case opts.HalfOpenMaxCalls < 0:
+		return nil, fmt.Errorf("opts.HalfOpenMaxCalls must not be negative")
+	}
+
+	if opts.FailureThreshold == 0 {
+		opts.FailureThreshold = DefaultCircuitBreakerFailureThreshold
+	}
+	if opts.MinRequests == 0 {
+		opts.MinRequests = DefaultCircuitBreakerMinRequests
+	}
+	if opts.Interval == 0 {
+		opts.Interval = DefaultCircuitBreakerInterval
+	}
+	if opts.OpenTimeout == 0 {
+		opts.OpenTimeout = DefaultCircuitBreakerOpenTimeout
+	}
+	if opts.HalfOpenMaxCalls == 0 {
+		opts.HalfOpenMaxCalls = DefaultCircuitBreakerHalfOpenMaxCalls
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = func(err error) bool {
+			return ClassifyError(err).IsTemporary()
+		}
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	b := &circuitBreaker{
+		circuits: make(map[string]*methodCircuit),
+		opts:     opts,
+	}
+
+	return NewClientWithDefaultHandler(func(ctx context.Context, caller ClientCaller) error {
+		if caller.Name() == "Close" {
+			return caller.Call(ctx, client)
+		}
+
+		isProbe, err := b.allow(caller.Name())
+		if err != nil {
+			return err
+		}
+
+		err = caller.Call(ctx, client)
+
+		b.record(caller.Name(), isProbe, err)
+
+		return err
+	}), nil
+}
+
+func (b *circuitBreaker) circuit(method string) *methodCircuit {
+	c, ok := b.circuits[method]
+	if !ok {
+		c = &methodCircuit{}
+		b.circuits[method] = c
+	}
+
+	return c
+}
+
+// allow returns an error if the call is rejected, or true if it is a probe
+// call for a half-open circuit.
+func (b *circuitBreaker) allow(method string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(method)
+
+	switch c.state {
+	case circuitClosed:
+		return false, nil
+
+	case circuitOpen:
+		until := c.openedAt.Add(b.opts.OpenTimeout)
+
+		if b.opts.Now().Before(until) {
+			return false, &CircuitOpenError{Method: method, Until: until}
+		}
+
+		c.state = circuitHalfOpen
+		c.halfOpenCalls = 0
+		c.halfOpenSuccesses = 0
+	}
+
+	if c.halfOpenCalls >= b.opts.HalfOpenMaxCalls {
+		return false, &CircuitOpenError{Method: method}
+	}
+
+	c.halfOpenCalls++
+	return true, nil
+}
+
+func (b *circuitBreaker) record(method string, isProbe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(method)
+	now := b.opts.Now()
+
+	isCanceled := ClassifyError(err) == ErrorCategoryCanceled
+	isFailure := err != nil && !isCanceled && b.opts.IsFailure(err)
+
+	if isProbe {
+		if c.state != circuitHalfOpen {
+			return
+		}
+
+		c.halfOpenCalls--
+
+		switch {
+		case isCanceled:
+		case isFailure:
+			b.open(c, now)
+		default:
+			c.halfOpenSuccesses++
+
+			if c.halfOpenSuccesses >= b.opts.HalfOpenMaxCalls {
+				*c = methodCircuit{}
+			}
+		}
+
+		return
+	}
+
+	// Ignore results of calls started before the circuit was opened.
+	if c.state != circuitClosed || isCanceled {
+		return
+	}
+
+	if c.windowStart.IsZero() || now.Sub(c.windowStart) >= b.opts.Interval {
+		c.windowStart = now
+		c.windowCalls = 0
+		c.windowFailures = 0
+	}
+
+	c.windowCalls++
+
+	if !isFailure {
+		c.failures = 0
+		return
+	}
+
+	c.failures++
+	c.windowFailures++
+
+	switch {
+	case b.opts.FailureThreshold > 0 && c.failures >= b.opts.FailureThreshold:
+		b.open(c, now)
+	case b.opts.FailureRatio != 0 && c.windowCalls >= b.opts.MinRequests && float64(c.windowFailures)/float64(c.windowCalls) >= b.opts.FailureRatio:
+		b.open(c, now)
+	}
+}
+
+func (b *circuitBreaker) open(c *methodCircuit, now time.Time) {
+	*c = methodCircuit{
+		state:    circuitOpen,
+		openedAt: now,
+	}
+}
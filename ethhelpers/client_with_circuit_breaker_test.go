@@ -0,0 +1,239 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClientWithCircuitBreaker(t *testing.T) {
+	type testArgs struct {
+		ctx     context.Context
+		client  ethhelpers.Client
+		mock    *mock.Mock
+		advance func(time.Duration)
+	}
+
+	hash := common.HexToHash("0x1234")
+
+	tests := []struct {
+		name string
+		opts ethhelpers.CircuitBreakerOptions
+		fn   func(*testing.T, testArgs)
+	}{
+		{
+			name: "opens after consecutive failures",
+			opts: ethhelpers.CircuitBreakerOptions{FailureThreshold: 2},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Twice()
+
+				for i := 0; i < 2; i++ {
+					_, err := args.client.BlockNumber(args.ctx)
+					assert.ErrorIs(t, err, syscall.ECONNRESET)
+				}
+
+				_, err := args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, ethhelpers.ErrCircuitOpen)
+
+				var circuitErr *ethhelpers.CircuitOpenError
+				if assert.True(t, errors.As(err, &circuitErr)) {
+					assert.Equal(t, "BlockNumber", circuitErr.Method)
+					assert.False(t, circuitErr.Until.IsZero())
+				}
+			},
+		}, {
+			name: "success resets consecutive failures",
+			opts: ethhelpers.CircuitBreakerOptions{FailureThreshold: 2},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(124), nil).Once()
+
+				for _, expected := range []uint64{0, 123, 0, 124} {
+					blockNumber, _ := args.client.BlockNumber(args.ctx)
+					assert.Equal(t, expected, blockNumber)
+				}
+			},
+		}, {
+			name: "errors that are not failures do not open",
+			opts: ethhelpers.CircuitBreakerOptions{FailureThreshold: 1},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("TransactionReceipt", args.ctx, hash).Return(nil, ethereum.NotFound).Twice()
+
+				for i := 0; i < 2; i++ {
+					_, err := args.client.TransactionReceipt(args.ctx, hash)
+					assert.ErrorIs(t, err, ethereum.NotFound)
+				}
+			},
+		}, {
+			name: "state is tracked per method",
+			opts: ethhelpers.CircuitBreakerOptions{FailureThreshold: 1},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("FilterLogs", args.ctx, ethereum.FilterQuery{}).Return(nil, syscall.ECONNRESET).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+
+				_, err := args.client.FilterLogs(args.ctx, ethereum.FilterQuery{})
+				assert.ErrorIs(t, err, syscall.ECONNRESET)
+				_, err = args.client.FilterLogs(args.ctx, ethereum.FilterQuery{})
+				assert.ErrorIs(t, err, ethhelpers.ErrCircuitOpen)
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(123), blockNumber)
+			},
+		}, {
+			name: "opens on failure ratio",
+			opts: ethhelpers.CircuitBreakerOptions{FailureThreshold: -1, FailureRatio: 0.5, MinRequests: 4},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+
+				for i := 0; i < 4; i++ {
+					_, err := args.client.BlockNumber(args.ctx)
+					assert.NotErrorIs(t, err, ethhelpers.ErrCircuitOpen)
+				}
+
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+
+				_, err := args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, syscall.ECONNRESET)
+				_, err = args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, ethhelpers.ErrCircuitOpen)
+			},
+		}, {
+			name: "closes after successful probe",
+			opts: ethhelpers.CircuitBreakerOptions{FailureThreshold: 1, OpenTimeout: time.Minute},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+
+				_, err := args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, syscall.ECONNRESET)
+
+				args.advance(59 * time.Second)
+
+				_, err = args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, ethhelpers.ErrCircuitOpen)
+
+				args.advance(time.Second)
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Twice()
+
+				for i := 0; i < 2; i++ {
+					blockNumber, err := args.client.BlockNumber(args.ctx)
+					assert.NoError(t, err)
+					assert.Equal(t, uint64(123), blockNumber)
+				}
+			},
+		}, {
+			name: "reopens after failed probe",
+			opts: ethhelpers.CircuitBreakerOptions{FailureThreshold: 1, OpenTimeout: time.Minute},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Twice()
+
+				_, err := args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, syscall.ECONNRESET)
+
+				args.advance(time.Minute)
+
+				_, err = args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, syscall.ECONNRESET)
+				_, err = args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, ethhelpers.ErrCircuitOpen)
+			},
+		}, {
+			name: "limits concurrent probes",
+			opts: ethhelpers.CircuitBreakerOptions{FailureThreshold: 1, OpenTimeout: time.Minute},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+
+				_, err := args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, syscall.ECONNRESET)
+
+				args.advance(time.Minute)
+
+				probeStarted := make(chan struct{})
+				probeDone := make(chan struct{})
+
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once().Run(func(mock.Arguments) {
+					close(probeStarted)
+					<-probeDone
+				})
+
+				go func() {
+					_, _ = args.client.BlockNumber(args.ctx)
+				}()
+
+				<-probeStarted
+
+				_, err = args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, ethhelpers.ErrCircuitOpen)
+
+				var circuitErr *ethhelpers.CircuitOpenError
+				if assert.True(t, errors.As(err, &circuitErr)) {
+					assert.True(t, circuitErr.Until.IsZero())
+				}
+
+				close(probeDone)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			now := time.Unix(1000000, 0)
+
+			mockClient := ethtesting.NewClientWithMock()
+			mockClient.Test(t)
+			mockClient.Mock().On("Close").Return(nil).Once()
+
+			opts := test.opts
+			opts.Now = func() time.Time { return now }
+
+			client, err := ethhelpers.NewClientWithCircuitBreaker(mockClient, opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			test.fn(t, testArgs{
+				ctx,
+				client,
+				mockClient.Mock(),
+				func(d time.Duration) { now = now.Add(d) },
+			})
+
+			client.Close()
+
+			mockClient.Mock().AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewClientWithCircuitBreaker_InvalidArguments(t *testing.T) {
+	_, err := ethhelpers.NewClientWithCircuitBreaker(nil, ethhelpers.CircuitBreakerOptions{})
+	assert.Error(t, err)
+
+	_, err = ethhelpers.NewClientWithCircuitBreaker(ethtesting.NewClientWithMock(), ethhelpers.CircuitBreakerOptions{FailureRatio: 1.5})
+	assert.Error(t, err)
+
+	_, err = ethhelpers.NewClientWithCircuitBreaker(ethtesting.NewClientWithMock(), ethhelpers.CircuitBreakerOptions{FailureThreshold: -1})
+	assert.Error(t, err)
+
+	_, err = ethhelpers.NewClientWithCircuitBreaker(ethtesting.NewClientWithMock(), ethhelpers.CircuitBreakerOptions{HalfOpenMaxCalls: -1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,208 @@
+package ethhelpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultCoalescingTimeout = 30 * time.Second
+
+type CoalescingOptions struct {
+	// IsCoalesced returns true if concurrent identical calls to the method
+	// should be merged, or uses DefaultRetryClass to merge all read methods
+	// except subscriptions and PendingNonceAt if nil.
+	IsCoalesced func(method string) bool
+
+	// TTL is the time the result of BlockNumber, HeaderByNumber(nil),
+	// SuggestGasPrice and SuggestGasTipCap is reused after the call returned.
+	//
+	// Pending state such as PendingNonceAt is never reused, as it changes
+	// with the caller's own transactions.
+	//
+	// Disabled if zero.
+	TTL time.Duration
+
+	// Timeout is the time a merged call may take before it is canceled, as
+	// it is not canceled when callers stop waiting, or
+	// DefaultCoalescingTimeout if zero.
+	Timeout time.Duration
+
+	// Now returns the current time, or time.Now if nil.
+	Now func() time.Time
+}
+
+type coalescedCall struct {
+	done    chan struct{}
+	results []interface{}
+	err     error
+	waiters int
+	expires time.Time
+}
+
+type clientWithCoalescing struct {
+	client Client
+	mu     sync.Mutex
+	calls  map[string]*coalescedCall
+	opts   CoalescingOptions
+}
+
+// NewClientWithCoalescing creates a client that merges concurrent calls with
+// the same method and arguments into a single call to the client, returning
+// the same result to every caller.
+//
+// The merged call is not canceled by a caller's context, a canceled caller
+// returns the context error and stops waiting. The merged call runs to
+// completion, or until opts.Timeout, even if all callers have stopped
+// waiting, so that its result can be reused within the TTL. Calls made after
+// all callers stopped waiting do not join the abandoned call.
+//
+// Results are shared between callers and must not be modified.
+func NewClientWithCoalescing(client Client, opts CoalescingOptions) (Client, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client must not be nil")
+	}
+	if opts.TTL < 0 {
+		return nil, fmt.Errorf("opts.TTL must not be negative")
+	}
+	if opts.Timeout < 0 {
+		return nil, fmt.Errorf("opts.Timeout must not be negative")
+	}
+
+	if opts.IsCoalesced == nil {
+		opts.IsCoalesced = func(method string) bool {
+			return DefaultRetryClass(method) == RetryClassRead && !strings.HasPrefix(method, "Subscribe") && method != "PendingNonceAt"
+		}
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultCoalescingTimeout
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	c := &clientWithCoalescing{
+		client: client,
+		calls:  make(map[string]*coalescedCall),
+		opts:   opts,
+	}
+
+	return NewClientWithDefaultHandler(c.handle), nil
+}
+
+func (c *clientWithCoalescing) handle(ctx context.Context, caller ClientCaller) error {
+	if !c.opts.IsCoalesced(caller.Name()) {
+		return caller.Call(ctx, c.client)
+	}
+
+	args, err := json.Marshal(caller.Args())
+	if err != nil {
+		return caller.Call(ctx, c.client)
+	}
+
+	key := caller.Name() + ":" + string(args)
+	f := c.join(ctx, key, caller.Name(), caller.Args())
+
+	select {
+	case <-f.done:
+		if f.err != nil {
+			return f.err
+		}
+
+		return caller.SetResults(f.results...)
+
+	case <-ctx.Done():
+		c.leave(key, f)
+		return ctx.Err()
+	}
+}
+
+func (c *clientWithCoalescing) join(ctx context.Context, key string, method string, args []interface{}) *coalescedCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, ok := c.calls[key]
+	if ok && !f.expires.IsZero() && !c.opts.Now().Before(f.expires) {
+		delete(c.calls, key)
+		ok = false
+	}
+
+	if !ok {
+		f = &coalescedCall{
+			done: make(chan struct{}),
+		}
+		c.calls[key] = f
+
+		go c.run(detachedContext{ctx}, key, f, method, args)
+	}
+
+	f.waiters++
+
+	return f
+}
+
+// leave removes the call once all callers have stopped waiting, so that later
+// callers do not join a call that might never return.
+func (c *clientWithCoalescing) leave(key string, f *coalescedCall) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f.waiters--
+
+	select {
+	case <-f.done:
+		return
+	default:
+	}
+
+	if f.waiters == 0 && c.calls[key] == f {
+		delete(c.calls, key)
+	}
+}
+
+func (c *clientWithCoalescing) run(ctx context.Context, key string, f *coalescedCall, method string, args []interface{}) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	results, err := callClientMethod(ctx, c.client, method, args)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f.results = results
+	f.err = err
+	close(f.done)
+
+	// An abandoned call may still fill the TTL cache if no newer call has
+	// replaced it.
+	if current, ok := c.calls[key]; ok && current != f {
+		return
+	}
+
+	if err == nil && c.opts.TTL != 0 && isReusableCall(method, args) {
+		f.expires = c.opts.Now().Add(c.opts.TTL)
+		c.calls[key] = f
+		return
+	}
+
+	delete(c.calls, key)
+}
+
+// isReusableCall returns true if the result of the call may be reused within
+// the TTL, which is limited to latest values that are the same for all
+// callers.
+func isReusableCall(method string, args []interface{}) bool {
+	switch method {
+	case "BlockNumber", "SuggestGasPrice", "SuggestGasTipCap":
+		return true
+	case "HeaderByNumber":
+		number, _ := args[0].(*big.Int)
+		return number == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,287 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClientWithCoalescing(t *testing.T) {
+	type testArgs struct {
+		ctx     context.Context
+		client  ethhelpers.Client
+		mock    *mock.Mock
+		advance func(time.Duration)
+	}
+
+	// blockingCall returns a function that waits for release, and a channel
+	// closed when the call has started.
+	blockingCall := func() (func(mock.Arguments), chan struct{}, chan struct{}) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+
+		return func(mock.Arguments) {
+			close(started)
+			<-release
+		}, started, release
+	}
+
+	tests := []struct {
+		name string
+		opts ethhelpers.CoalescingOptions
+		fn   func(*testing.T, testArgs)
+	}{
+		{
+			name: "concurrent BlockNumber calls are merged",
+			fn: func(t *testing.T, args testArgs) {
+				run, started, release := blockingCall()
+
+				args.mock.On("BlockNumber", mock.Anything).Return(uint64(123), nil).Once().Run(run)
+
+				var wg sync.WaitGroup
+
+				for i := 0; i < 5; i++ {
+					wg.Add(1)
+
+					go func() {
+						defer wg.Done()
+
+						blockNumber, err := args.client.BlockNumber(args.ctx)
+						assert.NoError(t, err)
+						assert.Equal(t, uint64(123), blockNumber)
+					}()
+				}
+
+				<-started
+				time.Sleep(10 * time.Millisecond)
+				close(release)
+				wg.Wait()
+			},
+		}, {
+			name: "calls with different arguments are not merged",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("HeaderByNumber", mock.Anything, big.NewInt(1)).Return(&types.Header{Number: big.NewInt(1)}, nil).Once()
+				args.mock.On("HeaderByNumber", mock.Anything, big.NewInt(2)).Return(&types.Header{Number: big.NewInt(2)}, nil).Once()
+
+				header, err := args.client.HeaderByNumber(args.ctx, big.NewInt(1))
+				assert.NoError(t, err)
+				assert.Equal(t, big.NewInt(1), header.Number)
+
+				header, err = args.client.HeaderByNumber(args.ctx, big.NewInt(2))
+				assert.NoError(t, err)
+				assert.Equal(t, big.NewInt(2), header.Number)
+			},
+		}, {
+			name: "sequential calls are not merged without ttl",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", mock.Anything).Return(uint64(123), nil).Once()
+				args.mock.On("BlockNumber", mock.Anything).Return(uint64(0), syscall.ECONNRESET).Once()
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(123), blockNumber)
+
+				_, err = args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, syscall.ECONNRESET)
+			},
+		}, {
+			name: "latest values are reused within ttl",
+			opts: ethhelpers.CoalescingOptions{TTL: time.Second},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(1)}, nil).Once()
+
+				for i := 0; i < 2; i++ {
+					header, err := args.client.HeaderByNumber(args.ctx, nil)
+					assert.NoError(t, err)
+					assert.Equal(t, big.NewInt(1), header.Number)
+				}
+
+				args.advance(time.Second)
+				args.mock.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(2)}, nil).Once()
+
+				header, err := args.client.HeaderByNumber(args.ctx, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, big.NewInt(2), header.Number)
+			},
+		}, {
+			name: "pending nonce is not reused within ttl",
+			opts: ethhelpers.CoalescingOptions{TTL: time.Second},
+			fn: func(t *testing.T, args testArgs) {
+				account := common.HexToAddress("0x1234")
+
+				args.mock.On("PendingNonceAt", mock.Anything, account).Return(uint64(1), nil).Once()
+				args.mock.On("PendingNonceAt", mock.Anything, account).Return(uint64(2), nil).Once()
+
+				for _, expected := range []uint64{1, 2} {
+					nonce, err := args.client.PendingNonceAt(args.ctx, account)
+					assert.NoError(t, err)
+					assert.Equal(t, expected, nonce)
+				}
+			},
+		}, {
+			name: "block-specific values are not reused within ttl",
+			opts: ethhelpers.CoalescingOptions{TTL: time.Second},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("HeaderByNumber", mock.Anything, big.NewInt(1)).Return(&types.Header{Number: big.NewInt(1)}, nil).Twice()
+
+				for i := 0; i < 2; i++ {
+					header, err := args.client.HeaderByNumber(args.ctx, big.NewInt(1))
+					assert.NoError(t, err)
+					assert.Equal(t, big.NewInt(1), header.Number)
+				}
+			},
+		}, {
+			name: "canceled caller does not cancel merged call",
+			fn: func(t *testing.T, args testArgs) {
+				run, started, release := blockingCall()
+
+				args.mock.On("SuggestGasTipCap", mock.Anything).Return(big.NewInt(1000), nil).Once().Run(run)
+
+				done := make(chan struct{})
+
+				go func() {
+					defer close(done)
+
+					tipCap, err := args.client.SuggestGasTipCap(args.ctx)
+					assert.NoError(t, err)
+					assert.Equal(t, big.NewInt(1000), tipCap)
+				}()
+
+				<-started
+
+				ctx, cancel := context.WithCancel(args.ctx)
+				cancel()
+
+				_, err := args.client.SuggestGasTipCap(ctx)
+				assert.ErrorIs(t, err, context.Canceled)
+
+				close(release)
+				<-done
+			},
+		}, {
+			name: "merged call completes and is reused when all callers are canceled",
+			opts: ethhelpers.CoalescingOptions{TTL: time.Second},
+			fn: func(t *testing.T, args testArgs) {
+				run, started, release := blockingCall()
+
+				args.mock.On("BlockNumber", mock.Anything).Return(uint64(123), nil).Once().Run(run)
+
+				ctx, cancel := context.WithCancel(args.ctx)
+
+				go func() {
+					<-started
+					cancel()
+				}()
+
+				_, err := args.client.BlockNumber(ctx)
+				assert.ErrorIs(t, err, context.Canceled)
+
+				close(release)
+				time.Sleep(10 * time.Millisecond)
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(123), blockNumber)
+			},
+		}, {
+			name: "abandoned call is not joined by later callers",
+			fn: func(t *testing.T, args testArgs) {
+				run, started, release := blockingCall()
+
+				args.mock.On("BlockNumber", mock.Anything).Return(uint64(123), nil).Once().Run(run)
+				args.mock.On("BlockNumber", mock.Anything).Return(uint64(124), nil).Once()
+
+				ctx, cancel := context.WithCancel(args.ctx)
+
+				go func() {
+					<-started
+					cancel()
+				}()
+
+				_, err := args.client.BlockNumber(ctx)
+				assert.ErrorIs(t, err, context.Canceled)
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(124), blockNumber)
+
+				close(release)
+				time.Sleep(10 * time.Millisecond)
+			},
+		}, {
+			name: "merged call is canceled after timeout",
+			opts: ethhelpers.CoalescingOptions{Timeout: 10 * time.Millisecond},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", mock.Anything).Return(ethtesting.CanceledMockCall()).Once().Run(func(mockArgs mock.Arguments) {
+					<-mockArgs.Get(0).(context.Context).Done()
+				})
+
+				_, err := args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+			},
+		}, {
+			name: "methods that are not coalesced are passed through",
+			opts: ethhelpers.CoalescingOptions{
+				IsCoalesced: func(method string) bool { return false },
+			},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(123), blockNumber)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			var mu sync.Mutex
+			now := time.Unix(1000000, 0)
+
+			mockClient := ethtesting.NewClientWithMock()
+			mockClient.Test(t)
+
+			opts := test.opts
+			opts.Now = func() time.Time {
+				mu.Lock()
+				defer mu.Unlock()
+				return now
+			}
+
+			client, err := ethhelpers.NewClientWithCoalescing(mockClient, opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			test.fn(t, testArgs{
+				ctx,
+				client,
+				mockClient.Mock(),
+				func(d time.Duration) {
+					mu.Lock()
+					defer mu.Unlock()
+					now = now.Add(d)
+				},
+			})
+
+			mockClient.Mock().AssertExpectations(t)
+		})
+	}
+}
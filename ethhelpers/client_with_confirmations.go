@@ -0,0 +1,227 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ConfirmationOptions configures when the logs of a block are confirmed and
+// sent by a log subscription.
+//
+// A log is confirmed when its block has at least Confirmations blocks on top
+// of it, or when it is covered by the finalized block if Finalized is set.
+type ConfirmationOptions struct {
+	// Confirmations is the number of blocks that must follow the block of a
+	// log, e.g. a log in block 100 with 2 confirmations is sent when the
+	// current block is 102.
+	//
+	// Must not be zero if Finalized is nil.
+	Confirmations uint64
+
+	// Finalized returns the number of the latest finalized block, if not
+	// nil. An ExtendedClient implements FinalizedBlockNumberReader.
+	Finalized FinalizedBlockNumberReader
+}
+
+func (opts ConfirmationOptions) validate() error {
+	if opts.Confirmations == 0 && opts.Finalized == nil {
+		return fmt.Errorf("opts.Confirmations must not be zero if opts.Finalized is nil")
+	}
+
+	return nil
+}
+
+// confirmedBlock returns the latest confirmed block when currentBlock is the
+// current block number, or false if no block is confirmed.
+func (opts ConfirmationOptions) confirmedBlock(ctx context.Context, currentBlock uint64) (uint64, bool, error) {
+	var confirmed uint64
+	var ok bool
+
+	if opts.Confirmations != 0 && currentBlock >= opts.Confirmations {
+		confirmed = currentBlock - opts.Confirmations
+		ok = true
+	}
+
+	if opts.Finalized != nil {
+		finalized, err := opts.Finalized.FinalizedBlockNumber(ctx)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to get finalized block number: %w", err)
+		}
+
+		if !ok || finalized > confirmed {
+			confirmed = finalized
+			ok = true
+		}
+	}
+
+	return confirmed, ok, nil
+}
+
+type clientWithConfirmations struct {
+	Client
+	opts ConfirmationOptions
+}
+
+// NewClientWithConfirmations creates a client whose log subscriptions hold
+// each log until it is confirmed, see SubscribeFilterLogsWithConfirmations.
+func NewClientWithConfirmations(client Client, opts ConfirmationOptions) (Client, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client must not be nil")
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	return &clientWithConfirmations{
+		Client: client,
+		opts:   opts,
+	}, nil
+}
+
+func (c *clientWithConfirmations) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return SubscribeFilterLogsWithConfirmations(ctx, c.Client, q, ch, c.opts)
+}
+
+// SubscribeFilterLogsWithConfirmations subscribes to logs with the client,
+// holding each log until it is confirmed by the headers of a SubscribeNewHead
+// subscription.
+//
+// Logs are sent in the order they were received. A log removed by a reorg
+// before it was confirmed is dropped together with its removal, while the
+// removal of a log that was already confirmed is sent.
+//
+// The context argument cancels the RPC requests that set up the
+// subscriptions but has no effect on the subscription after it has returned.
+func SubscribeFilterLogsWithConfirmations(ctx context.Context, client SubscribingClient, q ethereum.FilterQuery, ch chan<- types.Log, opts ConfirmationOptions) (ethereum.Subscription, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client must not be nil")
+	}
+	if ch == nil {
+		return nil, fmt.Errorf("ch must not be nil")
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	headers := make(chan *types.Header)
+
+	headSub, err := client.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to new heads: %w", err)
+	}
+
+	logs := make(chan types.Log)
+
+	logSub, err := client.SubscribeFilterLogs(ctx, q, logs)
+	if err != nil {
+		headSub.Unsubscribe()
+		return nil, err
+	}
+
+	subscriberCtx, cancel := context.WithCancel(context.Background())
+
+	s := &httpSubscription{
+		cancel: cancel,
+		err:    make(chan error, 1),
+		done:   make(chan struct{}),
+	}
+
+	go func(ctx context.Context) {
+		defer close(s.done)
+		defer headSub.Unsubscribe()
+		defer logSub.Unsubscribe()
+
+		if err := sendConfirmedLogs(ctx, opts, headSub, headers, logSub, logs, ch); err != nil {
+			s.err <- err
+		}
+	}(subscriberCtx)
+
+	return s, nil
+}
+
+// sendConfirmedLogs sends the logs as they are confirmed, until a
+// subscription fails or the context is done.
+func sendConfirmedLogs(ctx context.Context, opts ConfirmationOptions, headSub ethereum.Subscription, headers <-chan *types.Header, logSub ethereum.Subscription, logs <-chan types.Log, ch chan<- types.Log) error {
+	var held, confirmed []types.Log
+	var confirmedBlock uint64
+	var hasConfirmed bool
+
+	for {
+		var out chan<- types.Log
+		var next types.Log
+
+		if len(confirmed) != 0 {
+			out = ch
+			next = confirmed[0]
+		}
+
+		select {
+		case log := <-logs:
+			switch {
+			case log.Removed:
+				if idx := indexOfLog(held, log); idx != -1 {
+					held = append(held[:idx], held[idx+1:]...)
+				} else {
+					confirmed = append(confirmed, log)
+				}
+			case hasConfirmed && log.BlockNumber <= confirmedBlock:
+				confirmed = append(confirmed, log)
+			default:
+				held = append(held, log)
+			}
+
+		case header := <-headers:
+			n, ok, err := opts.confirmedBlock(ctx, header.Number.Uint64())
+			if err != nil {
+				return err
+			}
+			if !ok || (hasConfirmed && n <= confirmedBlock) {
+				continue
+			}
+
+			confirmedBlock = n
+			hasConfirmed = true
+
+			var remaining []types.Log
+
+			for _, log := range held {
+				if log.BlockNumber <= confirmedBlock {
+					confirmed = append(confirmed, log)
+				} else {
+					remaining = append(remaining, log)
+				}
+			}
+
+			held = remaining
+
+		case out <- next:
+			confirmed = confirmed[1:]
+
+		case err, ok := <-logSub.Err():
+			if !ok {
+				return fmt.Errorf("log subscription closed the error channel")
+			}
+			return err
+		case err, ok := <-headSub.Err():
+			if !ok {
+				return fmt.Errorf("head subscription closed the error channel")
+			}
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func indexOfLog(logs []types.Log, log types.Log) int {
+	for idx, l := range logs {
+		if l.BlockHash == log.BlockHash && l.TxHash == log.TxHash && l.Index == log.Index {
+			return idx
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,209 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSubscription struct {
+	err          chan error
+	unsubscribed chan struct{}
+}
+
+func newTestSubscription() *testSubscription {
+	return &testSubscription{
+		err:          make(chan error, 1),
+		unsubscribed: make(chan struct{}),
+	}
+}
+
+func (s *testSubscription) Unsubscribe()      { close(s.unsubscribed) }
+func (s *testSubscription) Err() <-chan error { return s.err }
+
+// testSubscribingClient returns subscriptions whose headers and logs are
+// sent by the test.
+type testSubscribingClient struct {
+	headers chan<- *types.Header
+	logs    chan<- types.Log
+	headSub *testSubscription
+	logSub  *testSubscription
+}
+
+func (c *testSubscribingClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	c.headers = ch
+	c.headSub = newTestSubscription()
+	return c.headSub, nil
+}
+
+func (c *testSubscribingClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	c.logs = ch
+	c.logSub = newTestSubscription()
+	return c.logSub, nil
+}
+
+func TestSubscribeFilterLogsWithConfirmations(t *testing.T) {
+	testLog := func(n uint64, fork byte) types.Log {
+		return types.Log{
+			BlockNumber: n,
+			BlockHash:   common.BytesToHash([]byte{byte(n), fork}),
+		}
+	}
+	removed := func(log types.Log) types.Log {
+		log.Removed = true
+		return log
+	}
+
+	tests := []struct {
+		name string
+		opts ethhelpers.ConfirmationOptions
+		fn   func(t *testing.T, head func(uint64), send func(...types.Log), expect func(...types.Log))
+	}{
+		{
+			name: "logs are held until confirmed",
+			opts: ethhelpers.ConfirmationOptions{Confirmations: 2},
+			fn: func(t *testing.T, head func(uint64), send func(...types.Log), expect func(...types.Log)) {
+				send(testLog(10, 0), testLog(11, 0))
+				head(11)
+				head(12)
+				expect(testLog(10, 0))
+
+				head(13)
+				expect(testLog(11, 0))
+
+				send(testLog(11, 1))
+				expect(testLog(11, 1))
+			},
+		}, {
+			name: "held logs removed by a reorg are dropped",
+			opts: ethhelpers.ConfirmationOptions{Confirmations: 1},
+			fn: func(t *testing.T, head func(uint64), send func(...types.Log), expect func(...types.Log)) {
+				send(testLog(10, 0), testLog(11, 0))
+				head(11)
+				expect(testLog(10, 0))
+
+				send(removed(testLog(11, 0)), testLog(11, 1))
+				head(12)
+				expect(testLog(11, 1))
+
+				send(removed(testLog(10, 0)))
+				expect(removed(testLog(10, 0)))
+			},
+		}, {
+			name: "logs are sent when finalized",
+			opts: ethhelpers.ConfirmationOptions{Finalized: testFinalizedReader(10)},
+			fn: func(t *testing.T, head func(uint64), send func(...types.Log), expect func(...types.Log)) {
+				send(testLog(10, 0), testLog(11, 0))
+				head(11)
+				expect(testLog(10, 0))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			client := &testSubscribingClient{}
+			logs := make(chan types.Log)
+
+			sub, err := ethhelpers.SubscribeFilterLogsWithConfirmations(ctx, client, ethereum.FilterQuery{}, logs, test.opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			head := func(n uint64) {
+				select {
+				case client.headers <- &types.Header{Number: new(big.Int).SetUint64(n)}:
+				case <-ctx.Done():
+					t.Fatal("timed out")
+				}
+			}
+			send := func(sent ...types.Log) {
+				for _, log := range sent {
+					select {
+					case client.logs <- log:
+					case <-ctx.Done():
+						t.Fatal("timed out")
+					}
+				}
+			}
+			expect := func(expected ...types.Log) {
+				for _, log := range expected {
+					select {
+					case r := <-logs:
+						assert.Equal(t, log, r)
+					case err := <-sub.Err():
+						t.Fatalf("unexpected error: %v", err)
+					case <-ctx.Done():
+						t.Fatal("timed out")
+					}
+				}
+			}
+
+			test.fn(t, head, send, expect)
+
+			select {
+			case log := <-logs:
+				assert.Fail(t, "unexpected log", "%v", log)
+			case <-time.After(10 * time.Millisecond):
+			}
+
+			sub.Unsubscribe()
+
+			assert.ErrorIs(t, <-sub.Err(), context.Canceled)
+			_, ok := <-sub.Err()
+			assert.False(t, ok)
+
+			<-client.headSub.unsubscribed
+			<-client.logSub.unsubscribed
+		})
+	}
+}
+
+func TestSubscribeFilterLogsWithConfirmations_SubscriptionError(t *testing.T) {
+	client := &testSubscribingClient{}
+
+	sub, err := ethhelpers.SubscribeFilterLogsWithConfirmations(context.Background(), client, ethereum.FilterQuery{}, make(chan types.Log), ethhelpers.ConfirmationOptions{Confirmations: 1})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	client.logSub.err <- ethereum.NotFound
+
+	assert.ErrorIs(t, <-sub.Err(), ethereum.NotFound)
+
+	sub.Unsubscribe()
+
+	<-client.headSub.unsubscribed
+	<-client.logSub.unsubscribed
+}
+
+func TestNewClientWithConfirmations_InvalidOptions(t *testing.T) {
+	client, err := ethhelpers.NewClientWithConfirmations(nil, ethhelpers.ConfirmationOptions{Confirmations: 1})
+	assert.EqualError(t, err, "client must not be nil")
+	assert.Nil(t, client)
+
+	client, err = ethhelpers.NewClientWithConfirmations(ethtesting.NewClientWithMock(), ethhelpers.ConfirmationOptions{})
+	assert.EqualError(t, err, "opts.Confirmations must not be zero if opts.Finalized is nil")
+	assert.Nil(t, client)
+}
+
+type testFinalizedReader uint64
+
+func (r testFinalizedReader) FinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	return uint64(r), nil
+}
@@ -10,73 +10,88 @@ import (
 	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
 	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 )
 
-func TestClientWithDefaultHandler(t *testing.T) {
-	type testArgs struct {
-		ctx    context.Context
-		client ethhelpers.Client
-		mock   *mock.Mock
-	}
-
-	preCallError := fmt.Errorf("pre call error")
-	postCallError := fmt.Errorf("post call error")
-	callError := fmt.Errorf("call error")
+func TestClientWithDefaultHandler_Results(t *testing.T) {
+	hash := common.HexToHash("0x1234")
+	callResult := &types.Transaction{}
+	replacedResult := &types.Transaction{}
 
 	tests := []struct {
-		name          string
-		call          func(*testing.T, testArgs)
-		preCallError  error
-		postCallError error
+		name    string
+		handler func(*testing.T, context.Context, ethhelpers.ClientCaller, ethhelpers.Client) error
+		mocked  bool
+		result  *types.Transaction
+		pending bool
 	}{
-		// BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error)
 		{
-			name: "BlockByHash returns success",
-			call: func(t *testing.T, args testArgs) {
-				hash := common.HexToHash("0x1234")
-				expectedResult := &types.Block{}
-
-				args.mock.On("BlockByHash", args.ctx, hash).Return(expectedResult, nil).Once()
-
-				r, err := args.client.BlockByHash(args.ctx, hash)
-				assert.NoError(t, err)
-				assert.Same(t, expectedResult, r)
+			name: "results are zero before call",
+			handler: func(t *testing.T, ctx context.Context, caller ethhelpers.ClientCaller, client ethhelpers.Client) error {
+				assert.Equal(t, []interface{}{(*types.Transaction)(nil), false}, caller.Results())
+				return caller.Call(ctx, client)
 			},
+			mocked:  true,
+			result:  callResult,
+			pending: true,
 		}, {
-			name: "BlockByHash call returns failure",
-			call: func(t *testing.T, args testArgs) {
-				hash := common.HexToHash("0x1234")
-
-				args.mock.On("BlockByHash", args.ctx, hash).Return(nil, callError).Once()
-
-				r, err := args.client.BlockByHash(args.ctx, hash)
-				assert.Same(t, callError, err)
-				assert.Nil(t, r)
+			name: "results are set after call",
+			handler: func(t *testing.T, ctx context.Context, caller ethhelpers.ClientCaller, client ethhelpers.Client) error {
+				err := caller.Call(ctx, client)
+				assert.Equal(t, []interface{}{callResult, true}, caller.Results())
+				return err
 			},
+			mocked:  true,
+			result:  callResult,
+			pending: true,
 		}, {
-			name: "BlockByHash pre handler returns failure",
-			call: func(t *testing.T, args testArgs) {
-				hash := common.HexToHash("0x1234")
-
-				r, err := args.client.BlockByHash(args.ctx, hash)
-				assert.Same(t, preCallError, err)
-				assert.Nil(t, r)
+			name: "results are replaced after call",
+			handler: func(t *testing.T, ctx context.Context, caller ethhelpers.ClientCaller, client ethhelpers.Client) error {
+				if err := caller.Call(ctx, client); err != nil {
+					return err
+				}
+				return caller.SetResults(replacedResult, false)
 			},
-			preCallError: preCallError,
+			mocked: true,
+			result: replacedResult,
 		}, {
-			name: "BlockByHash post handler returns failure",
-			call: func(t *testing.T, args testArgs) {
-				hash := common.HexToHash("0x1234")
-				expectedResult := &types.Block{}
-
-				args.mock.On("BlockByHash", args.ctx, hash).Return(expectedResult, nil).Once()
-
-				r, err := args.client.BlockByHash(args.ctx, hash)
-				assert.Same(t, postCallError, err)
-				assert.Nil(t, r)
+			name: "results are replaced without call",
+			handler: func(t *testing.T, ctx context.Context, caller ethhelpers.ClientCaller, client ethhelpers.Client) error {
+				return caller.SetResults(replacedResult, true)
+			},
+			result:  replacedResult,
+			pending: true,
+		}, {
+			name: "results are replaced with nil",
+			handler: func(t *testing.T, ctx context.Context, caller ethhelpers.ClientCaller, client ethhelpers.Client) error {
+				if err := caller.Call(ctx, client); err != nil {
+					return err
+				}
+				return caller.SetResults(nil, false)
+			},
+			mocked: true,
+		}, {
+			name: "set results with wrong count fails",
+			handler: func(t *testing.T, ctx context.Context, caller ethhelpers.ClientCaller, client ethhelpers.Client) error {
+				err := caller.SetResults(replacedResult)
+				assert.EqualError(t, err, "TransactionByHash: expected 2 results, got 1")
+				return caller.Call(ctx, client)
+			},
+			mocked:  true,
+			result:  callResult,
+			pending: true,
+		}, {
+			name: "set results with wrong type fails",
+			handler: func(t *testing.T, ctx context.Context, caller ethhelpers.ClientCaller, client ethhelpers.Client) error {
+				err := caller.SetResults(&types.Block{}, true)
+				assert.EqualError(t, err, "TransactionByHash: result 0 of type *types.Transaction cannot be assigned a *types.Block")
+				err = caller.SetResults(replacedResult, nil)
+				assert.EqualError(t, err, "TransactionByHash: result 1 of type bool cannot be nil")
+				assert.Equal(t, []interface{}{(*types.Transaction)(nil), false}, caller.Results())
+				return caller.Call(ctx, client)
 			},
-			postCallError: postCallError,
+			mocked:  true,
+			result:  callResult,
+			pending: true,
 		},
 	}
 
@@ -87,27 +102,171 @@ func TestClientWithDefaultHandler(t *testing.T) {
 			t.Parallel()
 
 			ctx := context.Background()
-			client := ethtesting.NewClientWithMock()
+			mockClient := ethtesting.NewClientWithMock()
 
-			test.call(t, testArgs{
-				ctx,
-				ethhelpers.NewClientWithDefaultHandler(func(ctx context.Context, caller ethhelpers.ClientCaller) (e error) {
-					if test.preCallError != nil {
-						return test.preCallError
-					}
+			if test.mocked {
+				mockClient.Mock().On("TransactionByHash", ctx, hash).Return(callResult, true, nil).Once()
+			}
 
-					e = caller.Call(ctx, client)
-
-					if test.postCallError != nil {
-						return test.postCallError
-					}
-
-					return
-				}),
-				client.Mock(),
+			client := ethhelpers.NewClientWithDefaultHandler(func(ctx context.Context, caller ethhelpers.ClientCaller) error {
+				return test.handler(t, ctx, caller, mockClient)
 			})
 
-			client.Mock().AssertExpectations(t)
+			tx, isPending, err := client.TransactionByHash(ctx, hash)
+			assert.NoError(t, err)
+			assert.Same(t, test.result, tx)
+			assert.Equal(t, test.pending, isPending)
+
+			mockClient.Mock().AssertExpectations(t)
 		})
 	}
 }
+
+func TestClientWithHandlers(t *testing.T) {
+	ctx := context.Background()
+	hash := common.HexToHash("0x1234")
+	tx := &types.Transaction{}
+
+	mockClient := ethtesting.NewClientWithMock()
+	mockClient.Test(t)
+	mockClient.Mock().On("BlockNumber", ctx).Return(uint64(123), nil).Once()
+	mockClient.Mock().On("TransactionByHash", ctx, hash).Return(tx, false, nil).Once()
+
+	var calls []string
+
+	recordHandler := func(prefix string) ethhelpers.ClientHandler {
+		return func(ctx context.Context, caller ethhelpers.ClientCaller) error {
+			calls = append(calls, prefix+":"+caller.Name())
+			return caller.Call(ctx, mockClient)
+		}
+	}
+
+	client := ethhelpers.NewClientWithHandlers(map[string]ethhelpers.ClientHandler{
+		"TransactionByHash": recordHandler("method"),
+		"ChainID":           nil,
+	}, recordHandler("default"))
+
+	n, err := client.BlockNumber(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(123), n)
+
+	r, isPending, err := client.TransactionByHash(ctx, hash)
+	assert.NoError(t, err)
+	assert.Same(t, tx, r)
+	assert.False(t, isPending)
+
+	assert.Equal(t, []string{"default:BlockNumber", "method:TransactionByHash"}, calls)
+
+	client = ethhelpers.NewClientWithHandlers(map[string]ethhelpers.ClientHandler{
+		"TransactionByHash": recordHandler("method"),
+	}, nil)
+
+	_, err = client.ChainID(ctx)
+	assert.EqualError(t, err, "no handler for ChainID")
+
+	mockClient.Mock().AssertExpectations(t)
+}
+
+func TestChainHandlers(t *testing.T) {
+	ctx := context.Background()
+	cachedResult := uint64(456)
+
+	var calls []string
+
+	recordHandler := func(name string) ethhelpers.ClientHandler {
+		return func(ctx context.Context, caller ethhelpers.ClientCaller) error {
+			calls = append(calls, name+":before")
+			err := caller.Call(ctx, nil)
+			calls = append(calls, fmt.Sprintf("%s:after:%v", name, caller.Results()))
+			return err
+		}
+	}
+
+	tests := []struct {
+		name     string
+		handlers func(ethhelpers.Client) []ethhelpers.ClientHandler
+		mocked   bool
+		result   uint64
+		err      error
+		calls    []string
+	}{
+		{
+			name: "no handlers",
+			handlers: func(ethhelpers.Client) []ethhelpers.ClientHandler {
+				return []ethhelpers.ClientHandler{nil}
+			},
+			err: fmt.Errorf("no handler for BlockNumber"),
+		}, {
+			name: "handlers are called in order",
+			handlers: func(client ethhelpers.Client) []ethhelpers.ClientHandler {
+				return []ethhelpers.ClientHandler{
+					recordHandler("first"),
+					nil,
+					recordHandler("second"),
+					func(ctx context.Context, caller ethhelpers.ClientCaller) error {
+						return caller.Call(ctx, client)
+					},
+				}
+			},
+			mocked: true,
+			result: 123,
+			calls:  []string{"first:before", "second:before", "second:after:[123]", "first:after:[123]"},
+		}, {
+			name: "handler skips rest of chain",
+			handlers: func(client ethhelpers.Client) []ethhelpers.ClientHandler {
+				return []ethhelpers.ClientHandler{
+					recordHandler("first"),
+					func(ctx context.Context, caller ethhelpers.ClientCaller) error {
+						return caller.SetResults(cachedResult)
+					},
+					recordHandler("second"),
+					func(ctx context.Context, caller ethhelpers.ClientCaller) error {
+						return caller.Call(ctx, client)
+					},
+				}
+			},
+			result: 456,
+			calls:  []string{"first:before", "first:after:[456]"},
+		}, {
+			name: "handler overrides error",
+			handlers: func(client ethhelpers.Client) []ethhelpers.ClientHandler {
+				return []ethhelpers.ClientHandler{
+					func(ctx context.Context, caller ethhelpers.ClientCaller) error {
+						if err := caller.Call(ctx, nil); err != nil {
+							return fmt.Errorf("wrapped: %w", err)
+						}
+						return nil
+					},
+					func(ctx context.Context, caller ethhelpers.ClientCaller) error {
+						return fmt.Errorf("call error")
+					},
+				}
+			},
+			err: fmt.Errorf("wrapped: call error"),
+		},
+	}
+
+	for _, test := range tests {
+		calls = nil
+
+		mockClient := ethtesting.NewClientWithMock()
+		mockClient.Test(t)
+
+		if test.mocked {
+			mockClient.Mock().On("BlockNumber", ctx).Return(uint64(123), nil).Once()
+		}
+
+		client := ethhelpers.NewClientWithDefaultHandler(ethhelpers.ChainHandlers(test.handlers(mockClient)...))
+
+		n, err := client.BlockNumber(ctx)
+		if test.err != nil {
+			assert.EqualError(t, err, test.err.Error(), test.name)
+		} else {
+			assert.NoError(t, err, test.name)
+		}
+		assert.Equal(t, test.result, n, test.name)
+		assert.Equal(t, test.calls, calls, test.name)
+
+		mockClient.Mock().AssertExpectations(t)
+	}
+}
@@ -2,37 +2,157 @@ package ethhelpers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// LogSubscriptionStrategy selects how the logs of a subscription are polled.
+type LogSubscriptionStrategy int
+
 const (
-	// Deprecated:
-	maxFilterLogWindow = 1000
+	// FilterLogsStrategy requests the logs of each range of blocks ticked
+	// with eth_getLogs, see SubscribeFilterLogsWithHTTP.
+	FilterLogsStrategy LogSubscriptionStrategy = iota
+
+	// FilterChangesStrategy polls a filter installed with eth_newFilter, see
+	// SubscribeFilterLogsWithFilterChanges.
+	FilterChangesStrategy
 )
 
+type HTTPSubscriptionsOptions struct {
+	// CreateTicker is a function that creates a block number ticker, see
+	// HTTPSubscriberOptions.CreateTicker.
+	CreateTicker func(ctx context.Context, fromBlock uint64) (BlockNumberTicker, error)
+
+	// LogStrategy selects how the logs of SubscribeFilterLogs are polled,
+	// with FilterLogsStrategy used by default.
+	LogStrategy LogSubscriptionStrategy
+
+	// RPCClient makes the filter requests, and must be set if LogStrategy is
+	// FilterChangesStrategy or to subscribe to pending transactions.
+	RPCClient *rpc.Client
+
+	// PendingTransactionsPollInterval is the time between polls of pending
+	// transaction filters, see
+	// HTTPPendingTransactionsSubscriberOptions.PollInterval.
+	PendingTransactionsPollInterval time.Duration
+
+	// Metrics records the health of the subscriptions, if not nil.
+	Metrics *SubscriptionMetrics
+}
+
+func (opts HTTPSubscriptionsOptions) validate() error {
+	if opts.CreateTicker == nil {
+		return fmt.Errorf("opts.CreateTicker must be set")
+	}
+
+	if opts.PendingTransactionsPollInterval < 0 {
+		return fmt.Errorf("opts.PendingTransactionsPollInterval must not be negative")
+	}
+
+	switch opts.LogStrategy {
+	case FilterLogsStrategy:
+	case FilterChangesStrategy:
+		if opts.RPCClient == nil {
+			return fmt.Errorf("opts.RPCClient must be set if opts.LogStrategy is FilterChangesStrategy")
+		}
+	default:
+		return fmt.Errorf("opts.LogStrategy is not a valid strategy")
+	}
+
+	return nil
+}
+
 type clientWithHTTPSubscriptions struct {
 	Client
-	createTicker func(context.Context, uint64) (BlockNumberTicker, error)
+	opts HTTPSubscriptionsOptions
 }
 
-// TODO: Add options.
-
-func NewClientWithHTTPSubscriptions(client Client, createTicker func(context.Context, uint64) (BlockNumberTicker, error)) Client {
+// NewClientWithHTTPSubscriptions creates a client that polls for new blocks
+// and logs with the block number ticker created by createTicker, see
+// NewClientWithHTTPSubscriptionsOptions.
+func NewClientWithHTTPSubscriptions(client Client, createTicker func(ctx context.Context, fromBlock uint64) (BlockNumberTicker, error)) Client {
 	return &clientWithHTTPSubscriptions{
-		Client:       client,
-		createTicker: createTicker,
+		Client: client,
+		opts: HTTPSubscriptionsOptions{
+			CreateTicker: createTicker,
+		},
+	}
+}
+
+// NewClientWithHTTPSubscriptionsOptions creates a client that polls for new
+// blocks and logs with the block number ticker created by opts.CreateTicker,
+// for use with nodes that do not support subscriptions, e.g. over HTTP.
+func NewClientWithHTTPSubscriptionsOptions(client Client, opts HTTPSubscriptionsOptions) (Client, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client must not be nil")
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
 	}
+
+	return &clientWithHTTPSubscriptions{
+		Client: client,
+		opts:   opts,
+	}, nil
 }
 
 // The context argument cancels the RPC request that sets up the subscription
 // but has no effect on the subscription after Subscribe has returned.
 func (c *clientWithHTTPSubscriptions) SubscribeFilterLogs(ctx context.Context, filterQuery ethereum.FilterQuery, logs chan<- types.Log) (ethereum.Subscription, error) {
+	if c.opts.LogStrategy == FilterChangesStrategy {
+		return SubscribeFilterLogsWithFilterChanges(ctx, &FilterChangesSubscriberOptions{
+			RPCClient:    c.opts.RPCClient,
+			CreateTicker: c.opts.CreateTicker,
+			FilterQuery:  filterQuery,
+			Logs:         logs,
+			Metrics:      c.opts.Metrics,
+		})
+	}
+
 	return SubscribeFilterLogsWithHTTP(ctx, &HTTPSubscriberOptions{
 		Client:       c.Client,
-		CreateTicker: c.createTicker,
+		CreateTicker: c.opts.CreateTicker,
 		FilterQuery:  filterQuery,
 		Logs:         logs,
+		Metrics:      c.opts.Metrics,
+	})
+}
+
+// SubscribeNewHead polls for new blocks and sends the header of each, see
+// SubscribeNewHeadWithHTTP.
+//
+// The context argument cancels the RPC request that sets up the subscription
+// but has no effect on the subscription after Subscribe has returned.
+func (c *clientWithHTTPSubscriptions) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return SubscribeNewHeadWithHTTP(ctx, &HTTPHeadSubscriberOptions{
+		Client:       c.Client,
+		CreateTicker: c.opts.CreateTicker,
+		Headers:      ch,
+		Metrics:      c.opts.Metrics,
+	})
+}
+
+// SubscribePendingTransactions polls a pending transaction filter and sends
+// each new pending transaction, see SubscribePendingTransactionsWithHTTP.
+//
+// Returns ErrNotSupported if opts.RPCClient is not set.
+//
+// The context argument cancels the RPC request that sets up the subscription
+// but has no effect on the subscription after Subscribe has returned.
+func (c *clientWithHTTPSubscriptions) SubscribePendingTransactions(ctx context.Context, ch chan<- *types.Transaction) (ethereum.Subscription, error) {
+	if c.opts.RPCClient == nil {
+		return nil, fmt.Errorf("SubscribePendingTransactions: %w", ErrNotSupported)
+	}
+
+	return SubscribePendingTransactionsWithHTTP(ctx, &HTTPPendingTransactionsSubscriberOptions{
+		RPCClient:    c.opts.RPCClient,
+		PollInterval: c.opts.PendingTransactionsPollInterval,
+		Transactions: ch,
+		Metrics:      c.opts.Metrics,
 	})
 }
@@ -2,6 +2,9 @@ package ethhelpers_test
 
 import (
 	"context"
+	"fmt"
+	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
 	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestClientWithHTTPSubscriptions_SubscribeFilterLogs(t *testing.T) {
@@ -120,3 +124,729 @@ func TestClientWithHTTPSubscriptions_SubscribeFilterLogs(t *testing.T) {
 		assert.Fail("timed out")
 	}
 }
+
+func TestClientWithHTTPSubscriptions_SubscribeNewHead(t *testing.T) {
+	headerErr := fmt.Errorf("header error")
+
+	tests := []struct {
+		name     string
+		ticks    []uint64
+		failAt   uint64
+		expected []uint64
+		err      error
+	}{
+		{
+			name:     "headers are sent in order without gaps",
+			ticks:    []uint64{10, 12, 13},
+			expected: []uint64{10, 11, 12, 13},
+		}, {
+			name:     "header error ends the subscription",
+			ticks:    []uint64{10, 12},
+			failAt:   11,
+			expected: []uint64{10},
+			err:      headerErr,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			ticker := &testBlockNumberTicker{
+				wait: make(chan ethhelpers.BlockNumber, len(test.ticks)),
+				err:  make(chan error),
+			}
+
+			for _, n := range test.ticks {
+				ticker.wait <- ethhelpers.BlockNumber{BlockNumber: n}
+			}
+
+			mockClient := ethtesting.NewClientWithMock()
+			mockClient.Test(t)
+			mockClient.Mock().On("BlockNumber", mock.Anything).Return(test.ticks[0], nil).Once()
+
+			for _, n := range test.expected {
+				mockClient.Mock().On("HeaderByNumber", mock.Anything, new(big.Int).SetUint64(n)).Return(&types.Header{Number: new(big.Int).SetUint64(n)}, nil).Once()
+			}
+			if test.failAt != 0 {
+				mockClient.Mock().On("HeaderByNumber", mock.Anything, new(big.Int).SetUint64(test.failAt)).Return(nil, headerErr).Once()
+			}
+
+			var tickerFromBlock uint64
+
+			client := ethhelpers.NewClientWithHTTPSubscriptions(mockClient, func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+				tickerFromBlock = fromBlock
+				return ticker, nil
+			})
+
+			headers := make(chan *types.Header)
+
+			sub, err := client.SubscribeNewHead(ctx, headers)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, test.ticks[0], tickerFromBlock)
+
+			for _, n := range test.expected {
+				select {
+				case header := <-headers:
+					assert.Equal(t, new(big.Int).SetUint64(n), header.Number)
+				case err := <-sub.Err():
+					assert.Fail(t, "unexpected error", "%v", err)
+					return
+				case <-ctx.Done():
+					assert.Fail(t, "timed out")
+					return
+				}
+			}
+
+			if test.err != nil {
+				select {
+				case err := <-sub.Err():
+					assert.ErrorIs(t, err, test.err)
+				case <-ctx.Done():
+					assert.Fail(t, "timed out")
+				}
+			}
+
+			sub.Unsubscribe()
+
+			if test.err == nil {
+				assert.ErrorIs(t, <-sub.Err(), context.Canceled)
+			}
+
+			_, ok := <-sub.Err()
+			assert.False(t, ok)
+
+			mockClient.Mock().AssertExpectations(t)
+		})
+	}
+}
+
+func TestSubscribeNewHeadWithHTTP_InvalidOptions(t *testing.T) {
+	createTicker := func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+		return nil, fmt.Errorf("not called")
+	}
+
+	tests := []struct {
+		name string
+		opts ethhelpers.HTTPHeadSubscriberOptions
+		err  string
+	}{
+		{
+			name: "missing client",
+			opts: ethhelpers.HTTPHeadSubscriberOptions{CreateTicker: createTicker, Headers: make(chan *types.Header)},
+			err:  "opts.Client must be set",
+		}, {
+			name: "missing ticker",
+			opts: ethhelpers.HTTPHeadSubscriberOptions{Client: ethtesting.NewClientWithMock(), Headers: make(chan *types.Header)},
+			err:  "opts.CreateTicker must be set",
+		}, {
+			name: "missing headers",
+			opts: ethhelpers.HTTPHeadSubscriberOptions{Client: ethtesting.NewClientWithMock(), CreateTicker: createTicker},
+			err:  "opts.Headers must be set",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			sub, err := ethhelpers.SubscribeNewHeadWithHTTP(context.Background(), &test.opts)
+			assert.EqualError(t, err, test.err)
+			assert.Nil(t, sub)
+		})
+	}
+}
+
+// testReorgChain is a chain of testReorgChainLength blocks with one log per
+// block, where the blocks from a number can be replaced to simulate a reorg.
+type testReorgChain struct {
+	mu      sync.Mutex
+	current uint64
+	headers map[uint64]*types.Header
+
+	// maxRange is the largest block range FilterLogs accepts, if not zero.
+	maxRange uint64
+
+	// finalized is the number of blocks from the current block to the
+	// finalized block.
+	finalized uint64
+}
+
+const testReorgChainLength = 32
+
+func newTestReorgChain(current uint64) *testReorgChain {
+	c := &testReorgChain{
+		current: current,
+		headers: make(map[uint64]*types.Header),
+	}
+
+	c.reorg(0, 0)
+
+	return c
+}
+
+// reorg replaces the blocks from fromBlock, with fork changing their hashes.
+func (c *testReorgChain) reorg(fromBlock uint64, fork byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for n := fromBlock; n < testReorgChainLength; n++ {
+		header := &types.Header{
+			Number: new(big.Int).SetUint64(n),
+			Extra:  []byte{fork},
+		}
+		if n != 0 {
+			header.ParentHash = c.headers[n-1].Hash()
+		}
+
+		c.headers[n] = header
+	}
+}
+
+func (c *testReorgChain) log(n uint64) types.Log {
+	return types.Log{
+		BlockNumber: n,
+		BlockHash:   c.headers[n].Hash(),
+		Data:        c.headers[n].Extra,
+	}
+}
+
+func (c *testReorgChain) BlockNumber(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.current, nil
+}
+
+func (c *testReorgChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if number.Uint64() > c.current {
+		return nil, ethereum.NotFound
+	}
+
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *testReorgChain) FinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.current - c.finalized, nil
+}
+
+func (c *testReorgChain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.maxRange != 0 && q.ToBlock.Uint64()-q.FromBlock.Uint64() >= c.maxRange {
+		return nil, fmt.Errorf("query returned more than 10000 results")
+	}
+
+	var logs []types.Log
+
+	for n := q.FromBlock.Uint64(); n <= q.ToBlock.Uint64() && n <= c.current; n++ {
+		logs = append(logs, c.log(n))
+	}
+
+	return logs, nil
+}
+
+func TestSubscribeFilterLogsWithHTTP_ReorgAndBackfill(t *testing.T) {
+	removed := func(log types.Log) types.Log {
+		log.Removed = true
+		return log
+	}
+
+	tests := []struct {
+		name      string
+		depth     uint64
+		fromBlock *big.Int
+		backfill  *ethhelpers.BackfillOptions
+		maxRange  uint64
+		confirm   uint64
+		finalized uint64
+		fn        func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string))
+	}{
+		{
+			name:  "logs of removed blocks are sent with removed set",
+			depth: 4,
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				tick(11)
+				expect(chain.log(10), chain.log(11))
+
+				oldLogs := []types.Log{chain.log(10), chain.log(11)}
+				chain.reorg(10, 1)
+
+				tick(12)
+				expect(removed(oldLogs[0]), removed(oldLogs[1]), chain.log(10), chain.log(11), chain.log(12))
+
+				tick(13)
+				expect(chain.log(13))
+
+				oldLog := chain.log(13)
+				chain.reorg(13, 2)
+
+				tick(14)
+				expect(removed(oldLog), chain.log(13), chain.log(14))
+			},
+		}, {
+			name:  "reorg of the last block",
+			depth: 4,
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				tick(11)
+				expect(chain.log(10), chain.log(11))
+
+				oldLog := chain.log(11)
+				chain.reorg(11, 1)
+
+				tick(12)
+				expect(removed(oldLog), chain.log(11), chain.log(12))
+			},
+		}, {
+			name:  "reorg deeper than the tracking depth fails",
+			depth: 1,
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				tick(11)
+				expect(chain.log(10), chain.log(11))
+
+				chain.reorg(10, 1)
+
+				tick(12)
+				expectErr("reorg is deeper than the tracking depth of 1 blocks")
+			},
+		}, {
+			name:      "past logs are backfilled before new logs",
+			fromBlock: big.NewInt(2),
+			backfill:  &ethhelpers.BackfillOptions{ChunkSize: 3},
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				for n := uint64(2); n < 10; n++ {
+					expect(chain.log(n))
+				}
+
+				tick(11)
+				expect(chain.log(10), chain.log(11))
+			},
+		}, {
+			name:      "backfill chunk size shrinks when the query is too large",
+			fromBlock: big.NewInt(2),
+			backfill:  &ethhelpers.BackfillOptions{ChunkSize: 8},
+			maxRange:  3,
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				for n := uint64(2); n < 10; n++ {
+					expect(chain.log(n))
+				}
+
+				tick(11)
+				expect(chain.log(10), chain.log(11))
+			},
+		}, {
+			name:      "backfill fails when the minimum chunk size is too large",
+			fromBlock: big.NewInt(2),
+			backfill:  &ethhelpers.BackfillOptions{ChunkSize: 8, MinChunkSize: 4},
+			maxRange:  3,
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				expectErr("failed to backfill logs of blocks 2 to 5: query returned more than 10000 results")
+			},
+		}, {
+			name:      "backfilled blocks are tracked for reorgs",
+			depth:     4,
+			fromBlock: big.NewInt(2),
+			backfill:  &ethhelpers.BackfillOptions{ChunkSize: 3},
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				for n := uint64(2); n < 10; n++ {
+					expect(chain.log(n))
+				}
+
+				tick(11)
+				expect(chain.log(10), chain.log(11))
+
+				oldLogs := []types.Log{chain.log(9), chain.log(10), chain.log(11)}
+				chain.reorg(9, 1)
+
+				tick(12)
+				expect(removed(oldLogs[0]), removed(oldLogs[1]), removed(oldLogs[2]), chain.log(9), chain.log(10), chain.log(11), chain.log(12))
+			},
+		}, {
+			name:    "logs are sent when confirmed",
+			confirm: 2,
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				tick(11)
+				tick(12)
+				expect(chain.log(10))
+
+				tick(14)
+				expect(chain.log(11), chain.log(12))
+			},
+		}, {
+			name:    "logs removed before they are confirmed are not sent",
+			depth:   4,
+			confirm: 2,
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				tick(12)
+				expect(chain.log(10))
+
+				chain.reorg(11, 1)
+
+				tick(13)
+				expect(chain.log(11))
+			},
+		}, {
+			name:      "logs are sent when finalized",
+			finalized: 3,
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				tick(12)
+				tick(13)
+				expect(chain.log(10))
+			},
+		}, {
+			name:      "backfill stops at the confirmed block",
+			fromBlock: big.NewInt(2),
+			backfill:  &ethhelpers.BackfillOptions{ChunkSize: 3},
+			confirm:   2,
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				for n := uint64(2); n <= 8; n++ {
+					expect(chain.log(n))
+				}
+
+				tick(11)
+				expect(chain.log(9))
+
+				tick(12)
+				expect(chain.log(10))
+			},
+		}, {
+			name:      "backfill waits for the first confirmed block",
+			fromBlock: big.NewInt(2),
+			backfill:  &ethhelpers.BackfillOptions{ChunkSize: 3},
+			maxRange:  3,
+			confirm:   20,
+			fn: func(t *testing.T, chain *testReorgChain, tick func(uint64), expect func(...types.Log), expectErr func(string)) {
+				tick(25)
+				for n := uint64(2); n <= 5; n++ {
+					expect(chain.log(n))
+				}
+
+				tick(29)
+				for n := uint64(6); n <= 9; n++ {
+					expect(chain.log(n))
+				}
+
+				tick(31)
+				expect(chain.log(10), chain.log(11))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			chain := newTestReorgChain(10)
+			chain.maxRange = test.maxRange
+			chain.finalized = test.finalized
+
+			var confirmations *ethhelpers.ConfirmationOptions
+
+			if test.confirm != 0 || test.finalized != 0 {
+				confirmations = &ethhelpers.ConfirmationOptions{Confirmations: test.confirm}
+
+				if test.finalized != 0 {
+					confirmations.Finalized = chain
+				}
+			}
+
+			ticker := &testBlockNumberTicker{
+				wait: make(chan ethhelpers.BlockNumber),
+				err:  make(chan error),
+			}
+
+			logs := make(chan types.Log, testReorgChainLength)
+
+			sub, err := ethhelpers.SubscribeFilterLogsWithHTTP(ctx, &ethhelpers.HTTPSubscriberOptions{
+				Client: chain,
+				CreateTicker: func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+					return ticker, nil
+				},
+				FilterQuery:        ethereum.FilterQuery{FromBlock: test.fromBlock},
+				Logs:               logs,
+				ReorgTrackingDepth: test.depth,
+				Backfill:           test.backfill,
+				Confirmations:      confirmations,
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer sub.Unsubscribe()
+
+			tick := func(n uint64) {
+				chain.mu.Lock()
+				if chain.current < n {
+					chain.current = n
+				}
+				chain.mu.Unlock()
+
+				select {
+				case ticker.wait <- ethhelpers.BlockNumber{BlockNumber: n}:
+				case <-ctx.Done():
+					t.Fatal("timed out")
+				}
+			}
+			expect := func(expected ...types.Log) {
+				var received []types.Log
+
+				for len(received) < len(expected) {
+					select {
+					case log := <-logs:
+						received = append(received, log)
+					case err := <-sub.Err():
+						t.Fatalf("unexpected error: %v", err)
+					case <-ctx.Done():
+						t.Fatal("timed out")
+					}
+				}
+
+				assert.Equal(t, expected, received)
+			}
+			expectErr := func(expected string) {
+				select {
+				case err := <-sub.Err():
+					assert.EqualError(t, err, expected)
+				case <-ctx.Done():
+					t.Fatal("timed out")
+				}
+			}
+
+			tick(10)
+			test.fn(t, chain, tick, expect, expectErr)
+
+			assert.Empty(t, logs)
+		})
+	}
+}
+
+func TestSubscribeFilterLogsWithHTTP_ReorgTrackingWithoutHeaders(t *testing.T) {
+	mockClient := ethtesting.NewClientWithMock()
+
+	sub, err := ethhelpers.SubscribeFilterLogsWithHTTP(context.Background(), &ethhelpers.HTTPSubscriberOptions{
+		Client: struct {
+			ethhelpers.BlockNumberReader
+			ethhelpers.FilterLogsReader
+		}{mockClient, mockClient},
+		CreateTicker: func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+			return nil, fmt.Errorf("not called")
+		},
+		Logs:               make(chan types.Log),
+		ReorgTrackingDepth: 4,
+	})
+	assert.EqualError(t, err, "opts.Client must implement HeaderByNumberReader when opts.ReorgTrackingDepth is set")
+	assert.Nil(t, sub)
+}
+
+func TestSubscribeFilterLogsWithHTTP_InvalidBackfillOptions(t *testing.T) {
+	sub, err := ethhelpers.SubscribeFilterLogsWithHTTP(context.Background(), &ethhelpers.HTTPSubscriberOptions{
+		Client: ethtesting.NewClientWithMock(),
+		CreateTicker: func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+			return nil, fmt.Errorf("not called")
+		},
+		Logs:     make(chan types.Log),
+		Backfill: &ethhelpers.BackfillOptions{ChunkSize: 100, MaxChunkSize: 10},
+	})
+	assert.EqualError(t, err, "invalid backfill options: opts.ChunkSize must be between opts.MinChunkSize and opts.MaxChunkSize")
+	assert.Nil(t, sub)
+}
+
+func TestSubscribeFilterLogsWithCheckpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		checkpoint *uint64
+		fromBlock  *big.Int
+		fn         func(t *testing.T, chain *testReorgChain, sub ethhelpers.CheckpointedSubscription, tick func(uint64), receive func(uint64) types.Log, expectCheckpoint func(uint64, bool))
+	}{
+		{
+			name:      "checkpoint is saved below the last acknowledged block",
+			fromBlock: big.NewInt(2),
+			fn: func(t *testing.T, chain *testReorgChain, sub ethhelpers.CheckpointedSubscription, tick func(uint64), receive func(uint64) types.Log, expectCheckpoint func(uint64, bool)) {
+				var logs []types.Log
+				for n := uint64(2); n < 10; n++ {
+					logs = append(logs, receive(n))
+				}
+
+				expectCheckpoint(0, false)
+
+				assert.NoError(t, sub.Ack(context.Background(), logs[2]))
+				expectCheckpoint(2, true)
+
+				assert.NoError(t, sub.Ack(context.Background(), logs[7]))
+				expectCheckpoint(7, true)
+
+				assert.EqualError(t, sub.Ack(context.Background(), logs[7]), "log was not sent or has already been acknowledged")
+
+				tick(11)
+				log10 := receive(10)
+				log11 := receive(11)
+
+				assert.NoError(t, sub.Ack(context.Background(), log10))
+				expectCheckpoint(7, true)
+
+				assert.NoError(t, sub.Ack(context.Background(), log11))
+				expectCheckpoint(9, true)
+			},
+		}, {
+			name:       "subscription resumes after the checkpoint",
+			checkpoint: func(n uint64) *uint64 { return &n }(6),
+			fromBlock:  big.NewInt(2),
+			fn: func(t *testing.T, chain *testReorgChain, sub ethhelpers.CheckpointedSubscription, tick func(uint64), receive func(uint64) types.Log, expectCheckpoint func(uint64, bool)) {
+				receive(7)
+				receive(8)
+				log9 := receive(9)
+
+				assert.NoError(t, sub.Ack(context.Background(), log9))
+				expectCheckpoint(7, true)
+
+				tick(11)
+				receive(10)
+				assert.NoError(t, sub.Ack(context.Background(), receive(11)))
+				expectCheckpoint(9, true)
+			},
+		}, {
+			name:       "reorged blocks after the checkpoint are sent again after resuming",
+			checkpoint: func(n uint64) *uint64 { return &n }(8),
+			fromBlock:  big.NewInt(2),
+			fn: func(t *testing.T, chain *testReorgChain, sub ethhelpers.CheckpointedSubscription, tick func(uint64), receive func(uint64) types.Log, expectCheckpoint func(uint64, bool)) {
+				receive(9)
+
+				chain.reorg(10, 1)
+				tick(11)
+
+				log10 := receive(10)
+				assert.Equal(t, []byte{1}, log10.Data)
+				assert.NoError(t, sub.Ack(context.Background(), receive(11)))
+				expectCheckpoint(9, true)
+			},
+		}, {
+			name: "subscription without a checkpoint or from block starts at the current block",
+			fn: func(t *testing.T, chain *testReorgChain, sub ethhelpers.CheckpointedSubscription, tick func(uint64), receive func(uint64) types.Log, expectCheckpoint func(uint64, bool)) {
+				tick(11)
+				receive(10)
+				assert.NoError(t, sub.Ack(context.Background(), receive(11)))
+				expectCheckpoint(9, true)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			chain := newTestReorgChain(10)
+			store := ethhelpers.NewMemoryCheckpointStore()
+
+			if test.checkpoint != nil {
+				assert.NoError(t, store.SaveCheckpoint(ctx, "test", *test.checkpoint))
+			}
+
+			ticker := &testBlockNumberTicker{
+				wait: make(chan ethhelpers.BlockNumber),
+				err:  make(chan error),
+			}
+
+			logs := make(chan types.Log, testReorgChainLength)
+
+			sub, err := ethhelpers.SubscribeFilterLogsWithCheckpoint(ctx, &ethhelpers.HTTPSubscriberOptions{
+				Client: chain,
+				CreateTicker: func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+					return ticker, nil
+				},
+				FilterQuery: ethereum.FilterQuery{FromBlock: test.fromBlock},
+				Logs:        logs,
+				Backfill:    &ethhelpers.BackfillOptions{ChunkSize: 3, MaxChunkSize: 3},
+			}, ethhelpers.CheckpointOptions{
+				Store:         store,
+				Key:           "test",
+				Confirmations: 2,
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer sub.Unsubscribe()
+
+			tick := func(n uint64) {
+				chain.mu.Lock()
+				if chain.current < n {
+					chain.current = n
+				}
+				chain.mu.Unlock()
+
+				select {
+				case ticker.wait <- ethhelpers.BlockNumber{BlockNumber: n}:
+				case <-ctx.Done():
+					t.Fatal("timed out")
+				}
+			}
+			receive := func(n uint64) types.Log {
+				select {
+				case log := <-logs:
+					assert.Equal(t, chain.log(n), log)
+					return log
+				case err := <-sub.Err():
+					t.Fatalf("unexpected error: %v", err)
+				case <-ctx.Done():
+					t.Fatal("timed out")
+				}
+				return types.Log{}
+			}
+			// The last log of a block range can be acknowledged before the
+			// range has been marked as delivered, so the checkpoint is saved
+			// eventually.
+			expectCheckpoint := func(expected uint64, expectedOk bool) {
+				assert.Eventually(t, func() bool {
+					blockNumber, ok, err := store.LoadCheckpoint(ctx, "test")
+					return err == nil && ok == expectedOk && blockNumber == expected
+				}, 5*time.Second, 5*time.Millisecond)
+			}
+
+			tick(10)
+			test.fn(t, chain, sub, tick, receive, expectCheckpoint)
+
+			assert.Empty(t, logs)
+		})
+	}
+}
+
+func TestSubscribeFilterLogsWithCheckpoint_InvalidOptions(t *testing.T) {
+	opts := &ethhelpers.HTTPSubscriberOptions{
+		Client: ethtesting.NewClientWithMock(),
+		CreateTicker: func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+			return nil, fmt.Errorf("not called")
+		},
+		Logs: make(chan types.Log),
+	}
+
+	sub, err := ethhelpers.SubscribeFilterLogsWithCheckpoint(context.Background(), opts, ethhelpers.CheckpointOptions{Key: "test"})
+	assert.EqualError(t, err, "invalid checkpoint options: opts.Store must be set")
+	assert.Nil(t, sub)
+
+	sub, err = ethhelpers.SubscribeFilterLogsWithCheckpoint(context.Background(), opts, ethhelpers.CheckpointOptions{Store: ethhelpers.NewMemoryCheckpointStore()})
+	assert.EqualError(t, err, "invalid checkpoint options: opts.Key must not be empty")
+	assert.Nil(t, sub)
+}
@@ -0,0 +1,265 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type LogMethodOptions struct {
+	// Level is the level of log entries for successful calls, or the level
+	// of LoggingOptions.Default and then DefaultLogMethodOptions if nil.
+	//
+	// log.LvlCrit is logged as log.LvlError, as log.Logger.Crit exits the
+	// process.
+	Level *log.Lvl
+
+	// ErrorLevel is the level of log entries for failed calls, or the level
+	// of LoggingOptions.Default and then DefaultLogMethodOptions if nil.
+	ErrorLevel *log.Lvl
+
+	// SampleEvery logs only one in every SampleEvery successful calls, failed
+	// calls are always logged. All calls are logged if zero or one.
+	SampleEvery uint64
+
+	// Disabled disables logging of the method.
+	Disabled bool
+}
+
+// DefaultLogMethodOptions is used for methods without options if
+// LoggingOptions.Default is nil.
+var DefaultLogMethodOptions = LogMethodOptions{
+	Level:      LogLevel(log.LvlDebug),
+	ErrorLevel: LogLevel(log.LvlWarn),
+}
+
+// LogLevel returns a pointer to lvl for use in LogMethodOptions.
+func LogLevel(lvl log.Lvl) *log.Lvl {
+	return &lvl
+}
+
+type LoggingOptions struct {
+	// Default is used for methods not in Methods, or DefaultLogMethodOptions
+	// if nil.
+	Default *LogMethodOptions
+
+	// Methods holds the options for each method, as named by
+	// ClientCaller.Name().
+	Methods map[string]LogMethodOptions
+}
+
+type clientWithLogging struct {
+	client Client
+	logger log.Logger
+	opts   LoggingOptions
+
+	mu    sync.Mutex
+	calls map[string]uint64
+}
+
+// NewClientWithLogging creates a client that logs each call to logger, or
+// log.Root() if nil.
+//
+// Each log entry holds the method name, formatted arguments, elapsed time,
+// a summary of the results or the error, and the correlation ID from the
+// context if set with ContextWithCorrelationID.
+func NewClientWithLogging(client Client, logger log.Logger, opts LoggingOptions) Client {
+	if logger == nil {
+		logger = log.Root()
+	}
+	if opts.Default == nil {
+		opts.Default = &DefaultLogMethodOptions
+	}
+
+	c := &clientWithLogging{
+		client: client,
+		logger: logger,
+		opts:   opts,
+		calls:  make(map[string]uint64),
+	}
+
+	return NewClientWithDefaultHandler(c.handle)
+}
+
+// methodOptions returns the options for the method, with unset levels taken
+// from the default options.
+func (c *clientWithLogging) methodOptions(method string) LogMethodOptions {
+	opts, ok := c.opts.Methods[method]
+	if !ok {
+		opts = *c.opts.Default
+	}
+
+	for _, defaults := range []*LogMethodOptions{c.opts.Default, &DefaultLogMethodOptions} {
+		if opts.Level == nil {
+			opts.Level = defaults.Level
+		}
+		if opts.ErrorLevel == nil {
+			opts.ErrorLevel = defaults.ErrorLevel
+		}
+	}
+
+	return opts
+}
+
+// sampled returns true if the successful call should be logged.
+func (c *clientWithLogging) sampled(method string, every uint64) bool {
+	if every <= 1 {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := c.calls[method]
+	c.calls[method] = n + 1
+
+	return n%every == 0
+}
+
+func (c *clientWithLogging) handle(ctx context.Context, caller ClientCaller) error {
+	opts := c.methodOptions(caller.Name())
+	if opts.Disabled {
+		return caller.Call(ctx, c.client)
+	}
+
+	start := time.Now()
+	err := caller.Call(ctx, c.client)
+	elapsed := time.Since(start)
+
+	lvl := *opts.Level
+
+	if err != nil {
+		lvl = *opts.ErrorLevel
+	} else if !c.sampled(caller.Name(), opts.SampleEvery) {
+		return nil
+	}
+
+	logCtx := []interface{}{
+		"method", caller.Name(),
+		"args", formatLogValues(caller.Args(), formatLogArg),
+		"elapsed", common.PrettyDuration(elapsed),
+	}
+
+	if err != nil {
+		logCtx = append(logCtx, "err", err)
+	} else {
+		logCtx = append(logCtx, "result", formatLogValues(caller.Results(), formatLogValue))
+	}
+
+	if id, ok := CorrelationIDFromContext(ctx); ok {
+		logCtx = append(logCtx, "correlation", id)
+	}
+
+	writeLog(c.logger, lvl, "Ethereum client call", logCtx)
+
+	return err
+}
+
+func writeLog(logger log.Logger, lvl log.Lvl, msg string, ctx []interface{}) {
+	switch lvl {
+	case log.LvlTrace:
+		logger.Trace(msg, ctx...)
+	case log.LvlDebug:
+		logger.Debug(msg, ctx...)
+	case log.LvlInfo:
+		logger.Info(msg, ctx...)
+	case log.LvlWarn:
+		logger.Warn(msg, ctx...)
+	default:
+		logger.Error(msg, ctx...)
+	}
+}
+
+func formatLogValues(values []interface{}, format func(interface{}) string) string {
+	s := make([]string, len(values))
+
+	for idx, v := range values {
+		s[idx] = format(v)
+	}
+
+	return strings.Join(s, " ")
+}
+
+// formatLogArg formats call arguments, where all *big.Int arguments of Client
+// methods are block numbers.
+func formatLogArg(v interface{}) string {
+	if number, ok := v.(*big.Int); ok {
+		return toBlockNumArg(number)
+	}
+
+	return formatLogValue(v)
+}
+
+// formatLogValue formats call arguments and results, keeping large values
+// such as blocks and logs short.
+func formatLogValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "nil"
+	case common.Hash:
+		return v.Hex()
+	case common.Address:
+		return v.Hex()
+	case *big.Int:
+		if v == nil {
+			return "nil"
+		}
+		return v.String()
+	case *types.Block:
+		if v == nil {
+			return "nil"
+		}
+		return fmt.Sprintf("block{number=%v hash=%s txs=%d}", v.Number(), v.Hash().Hex(), len(v.Transactions()))
+	case *types.Header:
+		if v == nil {
+			return "nil"
+		}
+		return fmt.Sprintf("header{number=%v hash=%s}", v.Number, v.Hash().Hex())
+	case *types.Transaction:
+		if v == nil {
+			return "nil"
+		}
+		return fmt.Sprintf("tx{hash=%s nonce=%d}", v.Hash().Hex(), v.Nonce())
+	case *types.Receipt:
+		if v == nil {
+			return "nil"
+		}
+		return fmt.Sprintf("receipt{tx=%s status=%d block=%v logs=%d}", v.TxHash.Hex(), v.Status, v.BlockNumber, len(v.Logs))
+	case []types.Log:
+		return fmt.Sprintf("logs{count=%d}", len(v))
+	case []byte:
+		return fmt.Sprintf("bytes{len=%d}", len(v))
+	case ethereum.CallMsg:
+		to := "nil"
+		if v.To != nil {
+			to = v.To.Hex()
+		}
+		return fmt.Sprintf("call{from=%s to=%s data=%d}", v.From.Hex(), to, len(v.Data))
+	case ethereum.FilterQuery:
+		if v.BlockHash != nil {
+			return fmt.Sprintf("filter{hash=%s addresses=%d topics=%d}", v.BlockHash.Hex(), len(v.Addresses), len(v.Topics))
+		}
+		from := "0x0"
+		if v.FromBlock != nil {
+			from = toBlockNumArg(v.FromBlock)
+		}
+		return fmt.Sprintf("filter{from=%s to=%s addresses=%d topics=%d}", from, toBlockNumArg(v.ToBlock), len(v.Addresses), len(v.Topics))
+	case ethereum.Subscription:
+		return "subscription"
+	case *ethereum.FeeHistory:
+		if v == nil {
+			return "nil"
+		}
+		return fmt.Sprintf("feehistory{oldest=%v blocks=%d}", v.OldestBlock, len(v.GasUsedRatio))
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
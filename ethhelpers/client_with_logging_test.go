@@ -0,0 +1,192 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"math/big"
+	"syscall"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger() (log.Logger, *[]*log.Record) {
+	var records []*log.Record
+
+	logger := log.New()
+	logger.SetHandler(log.FuncHandler(func(r *log.Record) error {
+		records = append(records, r)
+		return nil
+	}))
+
+	return logger, &records
+}
+
+func logRecordValues(r *log.Record) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	for idx := 0; idx+1 < len(r.Ctx); idx += 2 {
+		values[r.Ctx[idx].(string)] = r.Ctx[idx+1]
+	}
+
+	return values
+}
+
+func TestClientWithLogging(t *testing.T) {
+	ctx := ethhelpers.ContextWithCorrelationID(context.Background(), "block-1234")
+	hash := common.HexToHash("0x1234")
+	header := &types.Header{Number: big.NewInt(1234)}
+
+	mockClient := ethtesting.NewClientWithMock()
+	mockClient.Test(t)
+	mockClient.Mock().On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(header, nil).Once()
+	mockClient.Mock().On("TransactionReceipt", ctx, hash).Return(nil, syscall.ECONNRESET).Once()
+
+	logger, records := newTestLogger()
+	client := ethhelpers.NewClientWithLogging(mockClient, logger, ethhelpers.LoggingOptions{})
+
+	_, err := client.HeaderByNumber(ctx, nil)
+	assert.NoError(t, err)
+	_, err = client.TransactionReceipt(ctx, hash)
+	assert.Error(t, err)
+
+	if assert.Len(t, *records, 2) {
+		r := (*records)[0]
+		values := logRecordValues(r)
+
+		assert.Equal(t, log.LvlDebug, r.Lvl)
+		assert.Equal(t, "HeaderByNumber", values["method"])
+		assert.Equal(t, "latest", values["args"])
+		assert.Equal(t, "header{number=1234 hash="+header.Hash().Hex()+"}", values["result"])
+		assert.Equal(t, "block-1234", values["correlation"])
+		assert.NotContains(t, values, "err")
+
+		r = (*records)[1]
+		values = logRecordValues(r)
+
+		assert.Equal(t, log.LvlWarn, r.Lvl)
+		assert.Equal(t, "TransactionReceipt", values["method"])
+		assert.Equal(t, hash.Hex(), values["args"])
+		assert.Equal(t, syscall.ECONNRESET, values["err"])
+		assert.NotContains(t, values, "result")
+	}
+
+	mockClient.Mock().AssertExpectations(t)
+}
+
+func TestClientWithLogging_BigIntResults(t *testing.T) {
+	ctx := context.Background()
+	account := common.HexToAddress("0x1234")
+
+	mockClient := ethtesting.NewClientWithMock()
+	mockClient.Test(t)
+	mockClient.Mock().On("BalanceAt", ctx, account, big.NewInt(16)).Return(big.NewInt(1000), nil).Once()
+	mockClient.Mock().On("SuggestGasTipCap", ctx).Return(nil, nil).Once()
+
+	logger, records := newTestLogger()
+	client := ethhelpers.NewClientWithLogging(mockClient, logger, ethhelpers.LoggingOptions{})
+
+	_, err := client.BalanceAt(ctx, account, big.NewInt(16))
+	assert.NoError(t, err)
+	_, err = client.SuggestGasTipCap(ctx)
+	assert.NoError(t, err)
+
+	if assert.Len(t, *records, 2) {
+		values := logRecordValues((*records)[0])
+		assert.Equal(t, account.Hex()+" 0x10", values["args"])
+		assert.Equal(t, "1000", values["result"])
+
+		values = logRecordValues((*records)[1])
+		assert.Equal(t, "nil", values["result"])
+	}
+
+	mockClient.Mock().AssertExpectations(t)
+}
+
+func TestClientWithLogging_MethodOptions(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := ethtesting.NewClientWithMock()
+	mockClient.Test(t)
+	mockClient.Mock().On("BlockNumber", ctx).Return(uint64(1), nil).Times(4)
+	mockClient.Mock().On("BlockNumber", ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+	mockClient.Mock().On("ChainID", ctx).Return(big.NewInt(1), nil).Once()
+	mockClient.Mock().On("NetworkID", ctx).Return(big.NewInt(1), nil).Once()
+
+	logger, records := newTestLogger()
+	client := ethhelpers.NewClientWithLogging(mockClient, logger, ethhelpers.LoggingOptions{
+		Default: &ethhelpers.LogMethodOptions{Level: ethhelpers.LogLevel(log.LvlInfo), ErrorLevel: ethhelpers.LogLevel(log.LvlError)},
+		Methods: map[string]ethhelpers.LogMethodOptions{
+			"BlockNumber": {Level: ethhelpers.LogLevel(log.LvlTrace), ErrorLevel: ethhelpers.LogLevel(log.LvlCrit), SampleEvery: 3},
+			"NetworkID":   {Disabled: true},
+		},
+	})
+
+	for idx := 0; idx < 4; idx++ {
+		_, err := client.BlockNumber(ctx)
+		assert.NoError(t, err)
+	}
+
+	_, err := client.BlockNumber(ctx)
+	assert.Error(t, err)
+	_, err = client.ChainID(ctx)
+	assert.NoError(t, err)
+	_, err = client.NetworkID(ctx)
+	assert.NoError(t, err)
+
+	var methods []string
+	var lvls []log.Lvl
+
+	for _, r := range *records {
+		methods = append(methods, logRecordValues(r)["method"].(string))
+		lvls = append(lvls, r.Lvl)
+	}
+
+	assert.Equal(t, []string{"BlockNumber", "BlockNumber", "BlockNumber", "ChainID"}, methods)
+	assert.Equal(t, []log.Lvl{log.LvlTrace, log.LvlTrace, log.LvlError, log.LvlInfo}, lvls)
+
+	mockClient.Mock().AssertExpectations(t)
+}
+
+func TestClientWithLogging_PartialMethodOptions(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := ethtesting.NewClientWithMock()
+	mockClient.Test(t)
+	mockClient.Mock().On("BlockNumber", ctx).Return(uint64(1), nil).Once()
+	mockClient.Mock().On("BlockNumber", ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+	mockClient.Mock().On("ChainID", ctx).Return(big.NewInt(1), nil).Once()
+	mockClient.Mock().On("ChainID", ctx).Return(nil, syscall.ECONNRESET).Once()
+
+	logger, records := newTestLogger()
+	client := ethhelpers.NewClientWithLogging(mockClient, logger, ethhelpers.LoggingOptions{
+		Default: &ethhelpers.LogMethodOptions{Level: ethhelpers.LogLevel(log.LvlInfo)},
+		Methods: map[string]ethhelpers.LogMethodOptions{
+			"BlockNumber": {SampleEvery: 10},
+			"ChainID":     {ErrorLevel: ethhelpers.LogLevel(log.LvlError)},
+		},
+	})
+
+	_, err := client.BlockNumber(ctx)
+	assert.NoError(t, err)
+	_, err = client.BlockNumber(ctx)
+	assert.Error(t, err)
+	_, err = client.ChainID(ctx)
+	assert.NoError(t, err)
+	_, err = client.ChainID(ctx)
+	assert.Error(t, err)
+
+	var lvls []log.Lvl
+
+	for _, r := range *records {
+		lvls = append(lvls, r.Lvl)
+	}
+
+	assert.Equal(t, []log.Lvl{log.LvlInfo, log.LvlWarn, log.LvlInfo, log.LvlError}, lvls)
+
+	mockClient.Mock().AssertExpectations(t)
+}
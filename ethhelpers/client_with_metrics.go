@@ -0,0 +1,173 @@
+package ethhelpers
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+type methodMetrics struct {
+	duration metrics.Timer
+	inFlight metrics.Gauge
+}
+
+type clientMetrics struct {
+	registry metrics.Registry
+	prefix   string
+
+	mu      sync.Mutex
+	methods map[string]*methodMetrics
+}
+
+// NewClientWithMetrics creates a client that records metrics of each call in
+// the registry, or metrics.DefaultRegistry if nil.
+//
+// The following metrics are recorded for each method, as named by
+// ClientCaller.Name():
+//
+//	<prefix>/<method>/duration            timer of all calls
+//	<prefix>/<method>/inflight            gauge of calls in progress
+//	<prefix>/<method>/errors/<category>   counter of errors by ErrorCategory
+//
+// Metrics are only recorded if metrics.Enabled is true when the metric is
+// first used.
+func NewClientWithMetrics(client Client, registry metrics.Registry, prefix string) Client {
+	if registry == nil {
+		registry = metrics.DefaultRegistry
+	}
+
+	m := &clientMetrics{
+		registry: registry,
+		prefix:   prefix,
+		methods:  make(map[string]*methodMetrics),
+	}
+
+	return NewClientWithDefaultHandler(func(ctx context.Context, caller ClientCaller) error {
+		mm := m.method(caller.Name())
+
+		mm.inFlight.Inc(1)
+		defer mm.inFlight.Dec(1)
+
+		start := time.Now()
+		err := caller.Call(ctx, client)
+		mm.duration.UpdateSince(start)
+
+		if err != nil {
+			metrics.GetOrRegisterCounter(metricName(m.prefix, caller.Name(), "errors", ClassifyError(err).String()), m.registry).Inc(1)
+		}
+
+		return err
+	})
+}
+
+func (m *clientMetrics) method(name string) *methodMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mm, ok := m.methods[name]
+	if !ok {
+		mm = &methodMetrics{
+			duration: metrics.GetOrRegisterTimer(metricName(m.prefix, name, "duration"), m.registry),
+			inFlight: metrics.GetOrRegisterGauge(metricName(m.prefix, name, "inflight"), m.registry),
+		}
+		m.methods[name] = mm
+	}
+
+	return mm
+}
+
+// SubscriptionMetrics records the health of log and head subscriptions.
+//
+// A nil *SubscriptionMetrics is valid and records nothing.
+type SubscriptionMetrics struct {
+	logs      metrics.Counter
+	reorgs    metrics.Counter
+	tickerLag metrics.Timer
+
+	// lastBlock is the time in unix nanoseconds the last new block was seen.
+	lastBlock int64
+}
+
+// NewSubscriptionMetrics creates subscription metrics in the registry, or
+// metrics.DefaultRegistry if nil.
+//
+// The following metrics are recorded:
+//
+//	<prefix>/logs              counter of logs delivered
+//	<prefix>/reorgs            counter of reorgs detected
+//	<prefix>/ticker/lag        timer of the time between a block number tick
+//	                           and the logs or headers up to that block being
+//	                           delivered
+//	<prefix>/sincelastblock    gauge of milliseconds since the last new block
+//
+// Metrics are only recorded if metrics.Enabled is true when this function is
+// called.
+func NewSubscriptionMetrics(registry metrics.Registry, prefix string) *SubscriptionMetrics {
+	if registry == nil {
+		registry = metrics.DefaultRegistry
+	}
+
+	m := &SubscriptionMetrics{
+		logs:      metrics.GetOrRegisterCounter(metricName(prefix, "logs"), registry),
+		reorgs:    metrics.GetOrRegisterCounter(metricName(prefix, "reorgs"), registry),
+		tickerLag: metrics.GetOrRegisterTimer(metricName(prefix, "ticker", "lag"), registry),
+		lastBlock: time.Now().UnixNano(),
+	}
+
+	if metrics.Enabled {
+		registry.GetOrRegister(metricName(prefix, "sincelastblock"), metrics.NewFunctionalGauge(m.sinceLastBlock))
+	}
+
+	return m
+}
+
+func (m *SubscriptionMetrics) sinceLastBlock() int64 {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&m.lastBlock))).Milliseconds()
+}
+
+func (m *SubscriptionMetrics) blockReceived() {
+	if m == nil {
+		return
+	}
+
+	atomic.StoreInt64(&m.lastBlock, time.Now().UnixNano())
+}
+
+func (m *SubscriptionMetrics) logsDelivered(count int, tick time.Time) {
+	if m == nil {
+		return
+	}
+
+	m.logs.Inc(int64(count))
+	m.tickDelivered(tick)
+}
+
+func (m *SubscriptionMetrics) reorgDetected() {
+	if m == nil {
+		return
+	}
+
+	m.reorgs.Inc(1)
+}
+
+func (m *SubscriptionMetrics) tickDelivered(tick time.Time) {
+	if m == nil {
+		return
+	}
+
+	if !tick.IsZero() {
+		m.tickerLag.UpdateSince(tick)
+	}
+}
+
+func metricName(prefix string, parts ...string) string {
+	if prefix == "" {
+		return strings.Join(parts, "/")
+	}
+
+	return prefix + "/" + strings.Join(parts, "/")
+}
@@ -0,0 +1,148 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"math/big"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testBlockNumberTicker struct {
+	wait chan ethhelpers.BlockNumber
+	err  chan error
+}
+
+func (t *testBlockNumberTicker) Wait() <-chan ethhelpers.BlockNumber { return t.wait }
+func (t *testBlockNumberTicker) Err() <-chan error                   { return t.err }
+func (t *testBlockNumberTicker) Stop()                               {}
+
+func enableMetrics(t *testing.T) {
+	enabled := metrics.Enabled
+	metrics.Enabled = true
+
+	t.Cleanup(func() {
+		metrics.Enabled = enabled
+	})
+}
+
+func TestClientWithMetrics(t *testing.T) {
+	enableMetrics(t)
+
+	ctx := context.Background()
+	hash := common.HexToHash("0x1234")
+	registry := metrics.NewRegistry()
+
+	mockClient := ethtesting.NewClientWithMock()
+	mockClient.Test(t)
+	mockClient.Mock().On("BlockNumber", ctx).Return(uint64(123), nil).Once()
+	mockClient.Mock().On("BlockNumber", ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+	mockClient.Mock().On("TransactionReceipt", ctx, hash).Return(nil, ethereum.NotFound).Once()
+
+	client := ethhelpers.NewClientWithMetrics(mockClient, registry, "test")
+
+	_, err := client.BlockNumber(ctx)
+	assert.NoError(t, err)
+	_, err = client.BlockNumber(ctx)
+	assert.Error(t, err)
+	_, err = client.TransactionReceipt(ctx, hash)
+	assert.Error(t, err)
+
+	if timer, ok := registry.Get("test/BlockNumber/duration").(metrics.Timer); assert.True(t, ok) {
+		assert.Equal(t, int64(2), timer.Count())
+	}
+	if gauge, ok := registry.Get("test/BlockNumber/inflight").(metrics.Gauge); assert.True(t, ok) {
+		assert.Equal(t, int64(0), gauge.Value())
+	}
+	if counter, ok := registry.Get("test/BlockNumber/errors/temporary").(metrics.Counter); assert.True(t, ok) {
+		assert.Equal(t, int64(1), counter.Count())
+	}
+	if counter, ok := registry.Get("test/TransactionReceipt/errors/not-found").(metrics.Counter); assert.True(t, ok) {
+		assert.Equal(t, int64(1), counter.Count())
+	}
+
+	mockClient.Mock().AssertExpectations(t)
+}
+
+func TestSubscriptionMetrics_Disabled(t *testing.T) {
+	enabled := metrics.Enabled
+	metrics.Enabled = false
+
+	t.Cleanup(func() {
+		metrics.Enabled = enabled
+	})
+
+	registry := metrics.NewRegistry()
+	ethhelpers.NewSubscriptionMetrics(registry, "test/subscription")
+
+	assert.Nil(t, registry.Get("test/subscription/sincelastblock"))
+}
+
+func TestSubscriptionMetrics(t *testing.T) {
+	enableMetrics(t)
+
+	ctx := context.Background()
+	registry := metrics.NewRegistry()
+
+	ticker := &testBlockNumberTicker{
+		wait: make(chan ethhelpers.BlockNumber, 2),
+		err:  make(chan error),
+	}
+	ticker.wait <- ethhelpers.BlockNumber{BlockNumber: 10}
+	ticker.wait <- ethhelpers.BlockNumber{BlockNumber: 12, Timestamp: time.Now()}
+
+	mockClient := ethtesting.NewClientWithMock()
+	mockClient.Test(t)
+	mockClient.Mock().On("BlockNumber", mock.Anything).Return(uint64(10), nil).Once()
+	mockClient.Mock().On("FilterLogs", mock.Anything, ethereum.FilterQuery{
+		FromBlock: big.NewInt(10),
+		ToBlock:   big.NewInt(12),
+	}).Return([]types.Log{{Index: 1}, {Index: 2}}, nil).Once()
+
+	logs := make(chan types.Log, 2)
+
+	sub, err := ethhelpers.SubscribeFilterLogsWithHTTP(ctx, &ethhelpers.HTTPSubscriberOptions{
+		Client: mockClient,
+		CreateTicker: func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+			return ticker, nil
+		},
+		Logs:    logs,
+		Metrics: ethhelpers.NewSubscriptionMetrics(registry, "test/subscription"),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-logs:
+		case <-time.After(time.Second):
+			assert.Fail(t, "timed out")
+		}
+	}
+
+	assert.Eventually(t, func() bool {
+		counter, ok := registry.Get("test/subscription/logs").(metrics.Counter)
+		return ok && counter.Count() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	if timer, ok := registry.Get("test/subscription/ticker/lag").(metrics.Timer); assert.True(t, ok) {
+		assert.Equal(t, int64(1), timer.Count())
+	}
+	if gauge, ok := registry.Get("test/subscription/sincelastblock").(metrics.Gauge); assert.True(t, ok) {
+		assert.Less(t, gauge.Value(), int64(time.Second/time.Millisecond))
+	}
+
+	sub.Unsubscribe()
+
+	mockClient.Mock().AssertExpectations(t)
+}
@@ -0,0 +1,252 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRateLimitCost           = 1.0
+	DefaultRateLimitDecreaseFactor = 0.5
+
+	// rateLimitDecreaseCooldown is the minimum time between rate decreases,
+	// so a burst of rate-limited concurrent calls only lowers the rate once.
+	rateLimitDecreaseCooldown = 1 * time.Second
+)
+
+type RateLimitOptions struct {
+	// Rate is the number of cost units allowed per second.
+	Rate float64
+
+	// Burst is the maximum number of cost units that can be used at once, or
+	// Rate if zero.
+	Burst float64
+
+	// Costs is the cost of each call keyed by method name, as named by
+	// ClientCaller.Name(). Methods not in Costs use DefaultCost.
+	//
+	// See ethdefaults.ComputeUnitCosts for common provider costs.
+	Costs map[string]float64
+
+	// DefaultCost is the cost of methods not in Costs, or
+	// DefaultRateLimitCost if zero.
+	DefaultCost float64
+
+	// MinRate is the lowest rate allowed after rate-limited errors, or a tenth
+	// of Rate if zero.
+	MinRate float64
+
+	// DecreaseFactor is the factor the rate is multiplied by when a call
+	// returns a rate-limited error.
+	//
+	// Must be in the range (0.0, 1.0).
+	DecreaseFactor float64
+
+	// RecoveryRate is the increase of the rate per second until it is
+	// restored to Rate, or a sixtieth of Rate if zero.
+	RecoveryRate float64
+
+	// OnWait is called with the method name and the expected wait before the
+	// call is blocked.
+	OnWait func(method string, d time.Duration)
+
+	// Now returns the current time, or time.Now if nil.
+	Now func() time.Time
+
+	// Sleep waits for the duration or until the context is done, or uses a
+	// timer if nil.
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+type rateLimiter struct {
+	mu           sync.Mutex
+	tokens       float64
+	rate         float64
+	lastUpdate   time.Time
+	lastDecrease time.Time
+
+	opts RateLimitOptions
+}
+
+// NewClientWithRateLimit creates a client that limits calls using a token
+// bucket, where each call uses tokens according to the cost of the method.
+//
+// Calls block until enough tokens are available, and fail immediately with an
+// error wrapping context.DeadlineExceeded if the context deadline would expire
+// before then.
+//
+// The rate is lowered when a call returns an error classified as
+// ErrorCategoryRateLimited, and then gradually restored.
+func NewClientWithRateLimit(client Client, opts RateLimitOptions) (Client, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client must not be nil")
+	}
+
+	switch {
+	case opts.Rate <= 0:
+		return nil, fmt.Errorf("opts.Rate must be positive")
+	case opts.Burst < 0:
+		return nil, fmt.Errorf("opts.Burst must not be negative")
+	case opts.DefaultCost < 0:
+		return nil, fmt.Errorf("opts.DefaultCost must not be negative")
+	case opts.MinRate < 0 || opts.MinRate > opts.Rate:
+		return nil, fmt.Errorf("opts.MinRate must be in the range [0.0, opts.Rate]")
+	case opts.DecreaseFactor < 0.0 || opts.DecreaseFactor >= 1.0:
+		return nil, fmt.Errorf("opts.DecreaseFactor must be in the range (0.0, 1.0)")
+	case opts.RecoveryRate < 0:
+		return nil, fmt.Errorf("opts.RecoveryRate must not be negative")
+	}
+
+	for method, cost := range opts.Costs {
+		if cost < 0 {
+			return nil, fmt.Errorf("opts.Costs[%s] must not be negative", method)
+		}
+	}
+
+	if opts.Burst == 0 {
+		opts.Burst = opts.Rate
+	}
+	if opts.DefaultCost == 0 {
+		opts.DefaultCost = DefaultRateLimitCost
+	}
+	if opts.MinRate == 0 {
+		opts.MinRate = opts.Rate / 10
+	}
+	if opts.DecreaseFactor == 0 {
+		opts.DecreaseFactor = DefaultRateLimitDecreaseFactor
+	}
+	if opts.RecoveryRate == 0 {
+		opts.RecoveryRate = opts.Rate / 60
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.Sleep == nil {
+		opts.Sleep = sleepWithContext
+	}
+
+	l := &rateLimiter{
+		tokens:     opts.Burst,
+		rate:       opts.Rate,
+		lastUpdate: opts.Now(),
+		opts:       opts,
+	}
+
+	return NewClientWithDefaultHandler(func(ctx context.Context, caller ClientCaller) error {
+		if caller.Name() == "Close" {
+			return caller.Call(ctx, client)
+		}
+
+		if err := l.wait(ctx, caller.Name()); err != nil {
+			return err
+		}
+
+		err := caller.Call(ctx, client)
+
+		if ClassifyError(err) == ErrorCategoryRateLimited {
+			l.decrease()
+		}
+
+		return err
+	}), nil
+}
+
+func (l *rateLimiter) cost(method string) float64 {
+	if cost, ok := l.opts.Costs[method]; ok {
+		return cost
+	}
+
+	return l.opts.DefaultCost
+}
+
+// update adds tokens and restores the rate for the time passed since the last
+// update, must be called with the lock held.
+func (l *rateLimiter) update(now time.Time) {
+	elapsed := now.Sub(l.lastUpdate).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	l.lastUpdate = now
+
+	l.tokens += elapsed * l.rate
+	if l.tokens > l.opts.Burst {
+		l.tokens = l.opts.Burst
+	}
+
+	l.rate += elapsed * l.opts.RecoveryRate
+	if l.rate > l.opts.Rate {
+		l.rate = l.opts.Rate
+	}
+}
+
+// reserve takes the tokens for a call, returning the time to wait until they
+// are available.
+func (l *rateLimiter) reserve(ctx context.Context, method string, cost float64) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.opts.Now()
+	l.update(now)
+
+	l.tokens -= cost
+	if l.tokens >= 0 {
+		return 0, nil
+	}
+
+	d := time.Duration(-l.tokens / l.rate * float64(time.Second))
+
+	if deadline, ok := ctx.Deadline(); ok && now.Add(d).After(deadline) {
+		l.tokens += cost
+		return 0, fmt.Errorf("rate limit wait of %v for %s exceeds context deadline: %w", d, method, context.DeadlineExceeded)
+	}
+
+	return d, nil
+}
+
+func (l *rateLimiter) wait(ctx context.Context, method string) error {
+	cost := l.cost(method)
+	if cost == 0 {
+		return nil
+	}
+
+	d, err := l.reserve(ctx, method, cost)
+	if err != nil || d == 0 {
+		return err
+	}
+
+	if l.opts.OnWait != nil {
+		l.opts.OnWait(method, d)
+	}
+
+	if err := l.opts.Sleep(ctx, d); err != nil {
+		l.mu.Lock()
+		l.tokens += cost
+		l.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+func (l *rateLimiter) decrease() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.opts.Now()
+	l.update(now)
+
+	if !l.lastDecrease.IsZero() && now.Sub(l.lastDecrease) < rateLimitDecreaseCooldown {
+		return
+	}
+
+	l.lastDecrease = now
+
+	l.rate *= l.opts.DecreaseFactor
+	if l.rate < l.opts.MinRate {
+		l.rate = l.opts.MinRate
+	}
+}
@@ -0,0 +1,173 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClientWithRateLimit(t *testing.T) {
+	type testArgs struct {
+		ctx    context.Context
+		client ethhelpers.Client
+		mock   *mock.Mock
+		now    func() time.Time
+		waits  func() []time.Duration
+	}
+
+	tests := []struct {
+		name string
+		opts ethhelpers.RateLimitOptions
+		fn   func(*testing.T, testArgs)
+	}{
+		{
+			name: "calls within burst do not wait",
+			opts: ethhelpers.RateLimitOptions{Rate: 10, Burst: 2},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Times(3)
+
+				for i := 0; i < 3; i++ {
+					blockNumber, err := args.client.BlockNumber(args.ctx)
+					assert.NoError(t, err)
+					assert.Equal(t, uint64(123), blockNumber)
+				}
+
+				assert.Equal(t, []time.Duration{100 * time.Millisecond}, args.waits())
+			},
+		}, {
+			name: "uses method costs",
+			opts: ethhelpers.RateLimitOptions{
+				Rate:  10,
+				Costs: map[string]float64{"FilterLogs": 10, "ChainID": 0},
+			},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("FilterLogs", args.ctx, ethereum.FilterQuery{}).Return(nil, nil).Once()
+				args.mock.On("ChainID", args.ctx).Return(nil, nil).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+
+				_, err := args.client.FilterLogs(args.ctx, ethereum.FilterQuery{})
+				assert.NoError(t, err)
+				_, err = args.client.ChainID(args.ctx)
+				assert.NoError(t, err)
+				_, err = args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+
+				assert.Equal(t, []time.Duration{100 * time.Millisecond}, args.waits())
+			},
+		}, {
+			name: "fails without calling if wait exceeds context deadline",
+			opts: ethhelpers.RateLimitOptions{Rate: 1},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+
+				_, err := args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+
+				ctx, cancel := context.WithDeadline(args.ctx, args.now().Add(500*time.Millisecond))
+				defer cancel()
+
+				_, err = args.client.BlockNumber(ctx)
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+				assert.Empty(t, args.waits())
+			},
+		}, {
+			name: "lowers rate on rate-limited error",
+			opts: ethhelpers.RateLimitOptions{Rate: 10, Burst: 1},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), rpc.HTTPError{StatusCode: 429}).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+
+				_, err := args.client.BlockNumber(args.ctx)
+				assert.Error(t, err)
+				_, err = args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+
+				if waits := args.waits(); assert.Len(t, waits, 1) {
+					assert.InDelta(t, 200*time.Millisecond, waits[0], float64(time.Millisecond))
+				}
+			},
+		}, {
+			name: "does not lower rate on other errors",
+			opts: ethhelpers.RateLimitOptions{Rate: 10, Burst: 1},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+
+				_, err := args.client.BlockNumber(args.ctx)
+				assert.Error(t, err)
+				_, err = args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+
+				if waits := args.waits(); assert.Len(t, waits, 1) {
+					assert.InDelta(t, 100*time.Millisecond, waits[0], float64(time.Millisecond))
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			now := time.Now()
+
+			var waits []time.Duration
+			var sleeps []time.Duration
+
+			mockClient := ethtesting.NewClientWithMock()
+			mockClient.Test(t)
+			mockClient.Mock().On("Close").Return(nil).Once()
+
+			opts := test.opts
+			opts.Now = func() time.Time { return now }
+			opts.Sleep = func(ctx context.Context, d time.Duration) error {
+				sleeps = append(sleeps, d)
+				now = now.Add(d)
+				return nil
+			}
+			opts.OnWait = func(method string, d time.Duration) {
+				waits = append(waits, d)
+			}
+
+			client, err := ethhelpers.NewClientWithRateLimit(mockClient, opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			test.fn(t, testArgs{
+				ctx,
+				client,
+				mockClient.Mock(),
+				func() time.Time { return now },
+				func() []time.Duration { return waits },
+			})
+
+			client.Close()
+
+			assert.Equal(t, waits, sleeps)
+			mockClient.Mock().AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewClientWithRateLimit_InvalidArguments(t *testing.T) {
+	_, err := ethhelpers.NewClientWithRateLimit(nil, ethhelpers.RateLimitOptions{Rate: 1})
+	assert.Error(t, err)
+
+	_, err = ethhelpers.NewClientWithRateLimit(ethtesting.NewClientWithMock(), ethhelpers.RateLimitOptions{})
+	assert.Error(t, err)
+
+	_, err = ethhelpers.NewClientWithRateLimit(ethtesting.NewClientWithMock(), ethhelpers.RateLimitOptions{Rate: 1, Costs: map[string]float64{"BlockNumber": -1}})
+	assert.Error(t, err)
+}
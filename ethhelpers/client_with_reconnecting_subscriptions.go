@@ -0,0 +1,420 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// reconnectDedupeDepth is the number of blocks below the last block received
+// whose logs and headers are remembered, to skip those sent again after
+// subscribing again.
+const reconnectDedupeDepth = 128
+
+// ReconnectOptions configures how subscriptions are restored after they fail.
+type ReconnectOptions struct {
+	// Retry configures the back-off between attempts to subscribe again,
+	// with MaxAttempts and MaxDuration limiting the attempts after each
+	// failure.
+	Retry RetryOptions
+
+	// Backfill configures the requests made for the logs of the blocks
+	// missed while reconnecting, with the defaults used if nil.
+	Backfill *BackfillOptions
+}
+
+func (opts ReconnectOptions) withDefaults() (ReconnectOptions, error) {
+	retryOpts, err := opts.Retry.withDefaults()
+	if err != nil {
+		return ReconnectOptions{}, fmt.Errorf("invalid retry options: %w", err)
+	}
+
+	backfillOpts := BackfillOptions{}
+	if opts.Backfill != nil {
+		backfillOpts = *opts.Backfill
+	}
+
+	backfillOpts, err = backfillOpts.withDefaults()
+	if err != nil {
+		return ReconnectOptions{}, fmt.Errorf("invalid backfill options: %w", err)
+	}
+
+	opts.Retry = retryOpts
+	opts.Backfill = &backfillOpts
+
+	return opts, nil
+}
+
+// ReconnectSubscriberClient holds the methods used by
+// SubscribeFilterLogsWithReconnect.
+type ReconnectSubscriberClient interface {
+	BlockNumberReader
+	ethereum.LogFilterer
+}
+
+// ReconnectHeadSubscriberClient holds the methods used by
+// SubscribeNewHeadWithReconnect.
+type ReconnectHeadSubscriberClient interface {
+	BlockNumberReader
+	HeaderByNumberReader
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+type clientWithReconnectingSubscriptions struct {
+	Client
+	opts ReconnectOptions
+}
+
+// NewClientWithReconnectingSubscriptions creates a client whose subscriptions
+// subscribe again when they fail, see SubscribeFilterLogsWithReconnect and
+// SubscribeNewHeadWithReconnect.
+func NewClientWithReconnectingSubscriptions(client Client, opts ReconnectOptions) (Client, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client must not be nil")
+	}
+
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	return &clientWithReconnectingSubscriptions{
+		Client: client,
+		opts:   opts,
+	}, nil
+}
+
+func (c *clientWithReconnectingSubscriptions) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return SubscribeFilterLogsWithReconnect(ctx, c.Client, q, ch, c.opts)
+}
+
+func (c *clientWithReconnectingSubscriptions) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return SubscribeNewHeadWithReconnect(ctx, c.Client, ch, c.opts)
+}
+
+// SubscribeFilterLogsWithReconnect subscribes to logs with the client, and
+// subscribes again with back-off when the subscription fails.
+//
+// After subscribing again the logs of the blocks from the last block received
+// or backfilled up to the current block are requested with FilterLogs. Logs that were
+// already sent are skipped by block hash and log index, unless removed by a
+// reorg in between.
+//
+// The subscription only fails if the retry limits are reached, and the
+// context argument has no effect on the subscription after it has returned.
+func SubscribeFilterLogsWithReconnect(ctx context.Context, client ReconnectSubscriberClient, q ethereum.FilterQuery, ch chan<- types.Log, opts ReconnectOptions) (ethereum.Subscription, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client must not be nil")
+	}
+	if ch == nil {
+		return nil, fmt.Errorf("ch must not be nil")
+	}
+
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make(chan types.Log)
+
+	subscribe := func(ctx context.Context) (ethereum.Subscription, uint64, error) {
+		sub, err := client.SubscribeFilterLogs(ctx, q, logs)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		currentBlock, err := client.BlockNumber(ctx)
+		if err != nil {
+			sub.Unsubscribe()
+			return nil, 0, fmt.Errorf("failed to get current block number: %w", err)
+		}
+
+		return sub, currentBlock, nil
+	}
+
+	sub, lastBlock, err := subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type logID struct {
+		blockHash common.Hash
+		index     uint
+	}
+
+	sent := newRecentKeys()
+	backfiller := newLogBackfiller(*opts.Backfill)
+
+	send := func(ctx context.Context, log types.Log) error {
+		id := logID{blockHash: log.BlockHash, index: log.Index}
+
+		if log.Removed {
+			sent.remove(id)
+		} else {
+			if !sent.add(id, log.BlockNumber) {
+				return nil
+			}
+			if log.BlockNumber > lastBlock {
+				lastBlock = log.BlockNumber
+				sent.prune(lastBlock)
+			}
+		}
+
+		select {
+		case ch <- log:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return runReconnectingSubscription(sub, opts,
+		func(ctx context.Context) (ethereum.Subscription, error) {
+			sub, currentBlock, err := subscribe(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			// The logs of the last block may have been only partly sent.
+			err = backfiller.backfill(ctx, lastBlock, currentBlock,
+				func(ctx context.Context, fromBlock, toBlock uint64) ([]types.Log, error) {
+					return filterLogsInRange(ctx, client, q, fromBlock, toBlock)
+				},
+				func(logs []types.Log, toBlock uint64) error {
+					for _, log := range logs {
+						if err := send(ctx, log); err != nil {
+							return err
+						}
+					}
+
+					return nil
+				},
+			)
+			if err != nil {
+				sub.Unsubscribe()
+				return nil, err
+			}
+
+			// Blocks without logs are not requested again.
+			if currentBlock > lastBlock {
+				lastBlock = currentBlock
+				sent.prune(lastBlock)
+			}
+
+			return sub, nil
+		},
+		func(ctx context.Context, sub ethereum.Subscription) error {
+			for {
+				select {
+				case log := <-logs:
+					if err := send(ctx, log); err != nil {
+						return err
+					}
+				case err := <-sub.Err():
+					return subscriptionError(err)
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		},
+	), nil
+}
+
+// SubscribeNewHeadWithReconnect subscribes to new heads with the client, and
+// subscribes again with back-off when the subscription fails.
+//
+// After subscribing again the headers of the blocks after the last block
+// received up to the current block are requested with HeaderByNumber. Headers
+// that were already sent are skipped by hash.
+//
+// The subscription only fails if the retry limits are reached, and the
+// context argument has no effect on the subscription after it has returned.
+func SubscribeNewHeadWithReconnect(ctx context.Context, client ReconnectHeadSubscriberClient, ch chan<- *types.Header, opts ReconnectOptions) (ethereum.Subscription, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client must not be nil")
+	}
+	if ch == nil {
+		return nil, fmt.Errorf("ch must not be nil")
+	}
+
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(chan *types.Header)
+
+	subscribe := func(ctx context.Context) (ethereum.Subscription, uint64, error) {
+		sub, err := client.SubscribeNewHead(ctx, headers)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		currentBlock, err := client.BlockNumber(ctx)
+		if err != nil {
+			sub.Unsubscribe()
+			return nil, 0, fmt.Errorf("failed to get current block number: %w", err)
+		}
+
+		return sub, currentBlock, nil
+	}
+
+	sub, lastBlock, err := subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sent := newRecentKeys()
+
+	send := func(ctx context.Context, header *types.Header) error {
+		number := header.Number.Uint64()
+
+		if !sent.add(header.Hash(), number) {
+			return nil
+		}
+		if number > lastBlock {
+			lastBlock = number
+			sent.prune(lastBlock)
+		}
+
+		select {
+		case ch <- header:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return runReconnectingSubscription(sub, opts,
+		func(ctx context.Context) (ethereum.Subscription, error) {
+			sub, currentBlock, err := subscribe(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			for n := lastBlock + 1; n <= currentBlock; n++ {
+				header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+				if err != nil {
+					sub.Unsubscribe()
+					return nil, fmt.Errorf("failed to get header for block %d: %w", n, err)
+				}
+
+				if err := send(ctx, header); err != nil {
+					sub.Unsubscribe()
+					return nil, err
+				}
+			}
+
+			return sub, nil
+		},
+		func(ctx context.Context, sub ethereum.Subscription) error {
+			for {
+				select {
+				case header := <-headers:
+					if err := send(ctx, header); err != nil {
+						return err
+					}
+				case err := <-sub.Err():
+					return subscriptionError(err)
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		},
+	), nil
+}
+
+// runReconnectingSubscription calls receive with sub until it fails, and
+// then calls resubscribe with back-off to replace it, until the retry limits
+// are reached or the subscription is unsubscribed.
+func runReconnectingSubscription(sub ethereum.Subscription, opts ReconnectOptions, resubscribe func(ctx context.Context) (ethereum.Subscription, error), receive func(ctx context.Context, sub ethereum.Subscription) error) ethereum.Subscription {
+	subscriberCtx, cancel := context.WithCancel(context.Background())
+
+	s := &httpSubscription{
+		cancel: cancel,
+		err:    make(chan error, 1),
+		done:   make(chan struct{}),
+	}
+
+	go func(ctx context.Context) {
+		defer close(s.done)
+
+		for {
+			err := receive(ctx, sub)
+			sub.Unsubscribe()
+
+			if ctx.Err() != nil {
+				s.err <- ctx.Err()
+				return
+			}
+
+			backoff := newRetryBackoff(opts.Retry)
+
+			for {
+				if waitErr := backoff.wait(ctx, err); waitErr != nil {
+					s.err <- waitErr
+					return
+				}
+
+				if sub, err = resubscribe(ctx); err == nil {
+					break
+				}
+			}
+		}
+	}(subscriberCtx)
+
+	return s
+}
+
+// subscriptionError returns the error of a failed subscription, with a closed
+// error channel treated as a failure.
+func subscriptionError(err error) error {
+	if err == nil {
+		return fmt.Errorf("subscription closed the error channel")
+	}
+
+	return err
+}
+
+// recentKeys holds the keys of recently sent items with their block number,
+// forgetting those more than reconnectDedupeDepth blocks below the last block.
+type recentKeys struct {
+	keys map[interface{}]uint64
+}
+
+func newRecentKeys() *recentKeys {
+	return &recentKeys{
+		keys: make(map[interface{}]uint64),
+	}
+}
+
+// add returns false if the key is already present.
+func (r *recentKeys) add(key interface{}, blockNumber uint64) bool {
+	if _, ok := r.keys[key]; ok {
+		return false
+	}
+
+	r.keys[key] = blockNumber
+
+	return true
+}
+
+func (r *recentKeys) remove(key interface{}) {
+	delete(r.keys, key)
+}
+
+func (r *recentKeys) prune(lastBlock uint64) {
+	if lastBlock < reconnectDedupeDepth {
+		return
+	}
+
+	for key, blockNumber := range r.keys {
+		if blockNumber < lastBlock-reconnectDedupeDepth {
+			delete(r.keys, key)
+		}
+	}
+}
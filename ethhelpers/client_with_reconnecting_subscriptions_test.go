@@ -0,0 +1,339 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+)
+
+// testReconnectingClient is a chain with one log per block, where each
+// subscription is passed to the test through subs.
+type testReconnectingClient struct {
+	ethhelpers.Client
+
+	mu      sync.Mutex
+	current uint64
+
+	// failSubscribe is the number of subscribe calls that fail before the
+	// next one succeeds, or -1 if all fail.
+	failSubscribe int
+
+	// noLogs makes FilterLogs return no logs.
+	noLogs bool
+
+	// filtered receives the block range of each FilterLogs call.
+	filtered chan [2]uint64
+
+	subs    chan *testSubscription
+	logs    chan<- types.Log
+	headers chan<- *types.Header
+}
+
+func newTestReconnectingClient(current uint64) *testReconnectingClient {
+	return &testReconnectingClient{
+		Client:   ethtesting.NewClientWithMock(),
+		current:  current,
+		filtered: make(chan [2]uint64, 16),
+		subs:     make(chan *testSubscription, 1),
+	}
+}
+
+func testReconnectingHeader(n uint64) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(n)}
+}
+
+func (c *testReconnectingClient) setCurrent(n uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.current = n
+}
+
+func (c *testReconnectingClient) subscribe() (*testSubscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.failSubscribe != 0 {
+		if c.failSubscribe > 0 {
+			c.failSubscribe--
+		}
+
+		return nil, fmt.Errorf("subscribe failed")
+	}
+
+	sub := newTestSubscription()
+	c.subs <- sub
+
+	return sub, nil
+}
+
+func (c *testReconnectingClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	c.mu.Lock()
+	c.logs = ch
+	c.mu.Unlock()
+
+	return c.subscribe()
+}
+
+func (c *testReconnectingClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	c.mu.Lock()
+	c.headers = ch
+	c.mu.Unlock()
+
+	return c.subscribe()
+}
+
+func (c *testReconnectingClient) BlockNumber(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.current, nil
+}
+
+func (c *testReconnectingClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	select {
+	case c.filtered <- [2]uint64{q.FromBlock.Uint64(), q.ToBlock.Uint64()}:
+	default:
+	}
+
+	if c.noLogs {
+		return nil, nil
+	}
+
+	var logs []types.Log
+
+	for n := q.FromBlock.Uint64(); n <= q.ToBlock.Uint64(); n++ {
+		logs = append(logs, testFilterLog(n))
+	}
+
+	return logs, nil
+}
+
+func (c *testReconnectingClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return testReconnectingHeader(number.Uint64()), nil
+}
+
+func testReconnectOptions() ethhelpers.ReconnectOptions {
+	return ethhelpers.ReconnectOptions{
+		Retry: ethhelpers.RetryOptions{
+			Sleep: func(ctx context.Context, d time.Duration) error {
+				return ctx.Err()
+			},
+		},
+	}
+}
+
+func TestSubscribeFilterLogsWithReconnect(t *testing.T) {
+	tests := []struct {
+		name          string
+		failSubscribe int
+		fn            func(t *testing.T, client *testReconnectingClient, send func(...types.Log), expect func(...types.Log))
+	}{
+		{
+			name: "missed logs are backfilled and duplicates skipped",
+			fn: func(t *testing.T, client *testReconnectingClient, send func(...types.Log), expect func(...types.Log)) {
+				send(testFilterLog(11))
+				expect(testFilterLog(11))
+
+				client.setCurrent(13)
+				(<-client.subs).err <- fmt.Errorf("connection lost")
+
+				expect(testFilterLog(12), testFilterLog(13))
+
+				send(testFilterLog(13), testFilterLog(14))
+				expect(testFilterLog(14))
+			},
+		}, {
+			name:          "failed subscribe calls are retried",
+			failSubscribe: 2,
+			fn: func(t *testing.T, client *testReconnectingClient, send func(...types.Log), expect func(...types.Log)) {
+				client.setCurrent(11)
+				(<-client.subs).err <- fmt.Errorf("connection lost")
+
+				expect(testFilterLog(10), testFilterLog(11))
+			},
+		}, {
+			name: "removed logs are sent again",
+			fn: func(t *testing.T, client *testReconnectingClient, send func(...types.Log), expect func(...types.Log)) {
+				removed := testFilterLog(11)
+				removed.Removed = true
+
+				send(testFilterLog(11), removed, testFilterLog(11))
+				expect(testFilterLog(11), removed, testFilterLog(11))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			client := newTestReconnectingClient(10)
+			logs := make(chan types.Log, 16)
+
+			sub, err := ethhelpers.SubscribeFilterLogsWithReconnect(ctx, client, ethereum.FilterQuery{}, logs, testReconnectOptions())
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			client.mu.Lock()
+			client.failSubscribe = test.failSubscribe
+			ch := client.logs
+			client.mu.Unlock()
+
+			send := func(sent ...types.Log) {
+				for _, log := range sent {
+					select {
+					case ch <- log:
+					case <-ctx.Done():
+						t.Fatal("timed out")
+					}
+				}
+			}
+			expect := func(expected ...types.Log) {
+				for _, log := range expected {
+					select {
+					case r := <-logs:
+						assert.Equal(t, log, r)
+					case err := <-sub.Err():
+						t.Fatalf("unexpected error: %v", err)
+					case <-ctx.Done():
+						t.Fatal("timed out")
+					}
+				}
+			}
+
+			test.fn(t, client, send, expect)
+
+			sub.Unsubscribe()
+
+			assert.ErrorIs(t, <-sub.Err(), context.Canceled)
+			assert.Empty(t, logs)
+		})
+	}
+}
+
+func TestSubscribeFilterLogsWithReconnect_RetryLimit(t *testing.T) {
+	client := newTestReconnectingClient(10)
+
+	opts := testReconnectOptions()
+	opts.Retry.MaxAttempts = 3
+
+	sub, err := ethhelpers.SubscribeFilterLogsWithReconnect(context.Background(), client, ethereum.FilterQuery{}, make(chan types.Log), opts)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer sub.Unsubscribe()
+
+	client.mu.Lock()
+	client.failSubscribe = -1
+	client.mu.Unlock()
+
+	(<-client.subs).err <- fmt.Errorf("connection lost")
+
+	assert.EqualError(t, <-sub.Err(), "retry limit reached after 3 attempts: subscribe failed")
+}
+
+func TestSubscribeFilterLogsWithReconnect_BackfillWithoutLogs(t *testing.T) {
+	client := newTestReconnectingClient(10)
+	client.noLogs = true
+
+	sub, err := ethhelpers.SubscribeFilterLogsWithReconnect(context.Background(), client, ethereum.FilterQuery{}, make(chan types.Log), testReconnectOptions())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer sub.Unsubscribe()
+
+	client.setCurrent(13)
+	(<-client.subs).err <- fmt.Errorf("connection lost")
+	assert.Equal(t, [2]uint64{10, 13}, <-client.filtered)
+
+	client.setCurrent(15)
+	(<-client.subs).err <- fmt.Errorf("connection lost")
+	assert.Equal(t, [2]uint64{13, 15}, <-client.filtered)
+}
+
+func TestSubscribeNewHeadWithReconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client := newTestReconnectingClient(10)
+	headers := make(chan *types.Header, 16)
+
+	sub, err := ethhelpers.SubscribeNewHeadWithReconnect(ctx, client, headers, testReconnectOptions())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer sub.Unsubscribe()
+
+	client.mu.Lock()
+	ch := client.headers
+	client.mu.Unlock()
+
+	send := func(sent ...uint64) {
+		for _, n := range sent {
+			select {
+			case ch <- testReconnectingHeader(n):
+			case <-ctx.Done():
+				t.Fatal("timed out")
+			}
+		}
+	}
+	expect := func(expected ...uint64) {
+		for _, n := range expected {
+			select {
+			case header := <-headers:
+				assert.Equal(t, new(big.Int).SetUint64(n), header.Number)
+			case err := <-sub.Err():
+				t.Fatalf("unexpected error: %v", err)
+			case <-ctx.Done():
+				t.Fatal("timed out")
+			}
+		}
+	}
+
+	send(11)
+	expect(11)
+
+	client.setCurrent(13)
+	(<-client.subs).err <- fmt.Errorf("connection lost")
+
+	expect(12, 13)
+
+	send(13, 14)
+	expect(14)
+
+	assert.Empty(t, headers)
+}
+
+func TestNewClientWithReconnectingSubscriptions_InvalidOptions(t *testing.T) {
+	client, err := ethhelpers.NewClientWithReconnectingSubscriptions(nil, ethhelpers.ReconnectOptions{})
+	assert.EqualError(t, err, "client must not be nil")
+	assert.Nil(t, client)
+
+	client, err = ethhelpers.NewClientWithReconnectingSubscriptions(ethtesting.NewClientWithMock(), ethhelpers.ReconnectOptions{
+		Retry: ethhelpers.RetryOptions{Multiplier: 0.5},
+	})
+	assert.EqualError(t, err, "invalid retry options: opts.Multiplier must be 1.0 or greater")
+	assert.Nil(t, client)
+
+	client, err = ethhelpers.NewClientWithReconnectingSubscriptions(ethtesting.NewClientWithMock(), ethhelpers.ReconnectOptions{
+		Backfill: &ethhelpers.BackfillOptions{MinChunkSize: 10, MaxChunkSize: 5},
+	})
+	assert.EqualError(t, err, "invalid backfill options: opts.MaxChunkSize must not be less than opts.MinChunkSize")
+	assert.Nil(t, client)
+}
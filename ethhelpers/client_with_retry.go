@@ -4,70 +4,347 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"strings"
-	"syscall"
+	"math"
+	"math/rand"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+const (
+	DefaultRetryInitialDelay = 1 * time.Second
+	DefaultRetryMaxDelay     = 30 * time.Second
+	DefaultRetryMultiplier   = 2.0
+)
+
+// RetryOptions configures the back-off used when retrying client calls.
+//
+// Zero values are replaced by the defaults, except for MaxAttempts and
+// MaxDuration where a zero value means no limit.
+type RetryOptions struct {
+	// InitialDelay is the delay before the first retry.
+	InitialDelay time.Duration
+
+	// MaxDelay is the upper limit of the delay between retries, not including
+	// jitter.
+	MaxDelay time.Duration
+
+	// Multiplier is the factor the delay is multiplied by after each retry.
+	//
+	// Must be 1.0 or greater.
+	Multiplier float64
+
+	// Jitter randomizes each delay by up to +/- the given fraction of the
+	// delay, e.g. 0.1 for 10%.
+	//
+	// Must be in the range [0.0, 1.0].
+	Jitter float64
+
+	// MaxAttempts is the maximum number of calls made, including the first
+	// attempt.
+	MaxAttempts int
+
+	// MaxDuration is the maximum time spent since the first attempt, no
+	// retries are made if the next delay would exceed it.
+	MaxDuration time.Duration
+
+	// Now returns the current time, or time.Now if nil.
+	Now func() time.Time
+
+	// Sleep waits for the duration or until the context is done, or uses a
+	// timer if nil.
+	//
+	// Must return the context error if the context is done before the
+	// duration has passed.
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+func (opts RetryOptions) withDefaults() (RetryOptions, error) {
+	if opts.InitialDelay == 0 {
+		opts.InitialDelay = DefaultRetryInitialDelay
+	}
+	if opts.MaxDelay == 0 {
+		opts.MaxDelay = DefaultRetryMaxDelay
+	}
+	if opts.Multiplier == 0 {
+		opts.Multiplier = DefaultRetryMultiplier
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.Sleep == nil {
+		opts.Sleep = sleepWithContext
+	}
+
+	switch {
+	case opts.InitialDelay < 0:
+		return RetryOptions{}, fmt.Errorf("opts.InitialDelay must not be negative")
+	case opts.MaxDelay < opts.InitialDelay:
+		return RetryOptions{}, fmt.Errorf("opts.MaxDelay must not be less than opts.InitialDelay")
+	case opts.Multiplier < 1.0:
+		return RetryOptions{}, fmt.Errorf("opts.Multiplier must be 1.0 or greater")
+	case opts.Jitter < 0.0 || opts.Jitter > 1.0:
+		return RetryOptions{}, fmt.Errorf("opts.Jitter must be in the range [0.0, 1.0]")
+	case opts.MaxAttempts < 0:
+		return RetryOptions{}, fmt.Errorf("opts.MaxAttempts must not be negative")
+	case opts.MaxDuration < 0:
+		return RetryOptions{}, fmt.Errorf("opts.MaxDuration must not be negative")
+	}
+
+	return opts, nil
+}
+
+// Delay returns the delay before the retry following the given number of
+// failed attempts, not including jitter.
+func (opts RetryOptions) Delay(failedAttempts int) time.Duration {
+	opts, err := opts.withDefaults()
+	if err != nil || failedAttempts <= 0 {
+		return 0
+	}
+
+	d := float64(opts.InitialDelay) * math.Pow(opts.Multiplier, float64(failedAttempts-1))
+	if d > float64(opts.MaxDelay) {
+		return opts.MaxDelay
+	}
+
+	return time.Duration(d)
+}
+
+func (opts RetryOptions) jitter(d time.Duration) time.Duration {
+	if opts.Jitter == 0 || d <= 0 {
+		return d
+	}
+
+	return d + time.Duration((rand.Float64()*2-1)*opts.Jitter*float64(d))
+}
+
+// retryBackoff keeps track of the attempts of a single retried call.
+type retryBackoff struct {
+	opts     RetryOptions
+	start    time.Time
+	attempts int
+}
+
+func newRetryBackoff(opts RetryOptions) *retryBackoff {
+	return &retryBackoff{
+		opts:  opts,
+		start: opts.Now(),
+	}
+}
+
+// wait sleeps until the next attempt, returning an error if the retry limits
+// have been reached or the context is done.
+func (b *retryBackoff) wait(ctx context.Context, lastErr error) error {
+	b.attempts++
+
+	if b.opts.MaxAttempts != 0 && b.attempts >= b.opts.MaxAttempts {
+		return fmt.Errorf("retry limit reached after %d attempts: %w", b.attempts, lastErr)
+	}
+
+	d := b.opts.jitter(b.opts.Delay(b.attempts))
+
+	if b.opts.MaxDuration != 0 && b.opts.Now().Sub(b.start)+d > b.opts.MaxDuration {
+		return fmt.Errorf("retry duration limit reached after %d attempts: %w", b.attempts, lastErr)
+	}
+
+	return b.opts.Sleep(ctx, d)
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RetryClass determines how failed calls to a client method are retried.
+type RetryClass int
+
+const (
+	// RetryClassRead is used for calls without side-effects, which are retried
+	// freely.
+	RetryClassRead RetryClass = iota
+
+	// RetryClassSend is used for calls that change state, which are only
+	// retried if the error shows the node never received the call.
+	RetryClassSend
+
+	// RetryClassNone is used for calls that are never retried.
+	RetryClassNone
+)
+
+// DefaultRetryClass returns the retry class of a client method, as named by
+// ClientCaller.Name().
+func DefaultRetryClass(method string) RetryClass {
+	switch method {
+	case "SendTransaction":
+		return RetryClassSend
+	case "Close":
+		return RetryClassNone
+	default:
+		return RetryClassRead
+	}
+}
+
+// NonRetryableError wraps an error that error handlers must return without
+// retrying the call.
+type NonRetryableError struct {
+	Err error
+}
+
+func (e *NonRetryableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NonRetryableError) Unwrap() error {
+	return e.Err
+}
+
+// NewClientWithRetry creates a client that passes calls to errorHandler, using
+// DefaultRetryClass to decide how each method is retried.
 func NewClientWithRetry(client Client, errorHandler func(context.Context, func(context.Context) error) error) Client {
+	return NewClientWithRetryClasses(client, errorHandler, DefaultRetryClass)
+}
+
+// NewClientWithRetryClasses creates a client that passes calls to
+// errorHandler, using retryClass to decide how each method is retried.
+//
+// Calls with RetryClassSend return a NonRetryableError to the error handler
+// unless the error shows the node never received the call. Errors showing the
+// transaction was already received by the node are treated as success.
+func NewClientWithRetryClasses(client Client, errorHandler func(context.Context, func(context.Context) error) error, retryClass func(method string) RetryClass) Client {
 	return NewClientWithDefaultHandler(func(ctx context.Context, caller ClientCaller) error {
-		return errorHandler(ctx, func(ctx context.Context) error {
+		var fn func(context.Context) error
+
+		switch retryClass(caller.Name()) {
+		case RetryClassNone:
 			return caller.Call(ctx, client)
-		})
+		case RetryClassSend:
+			fn = retryableSendCall(client, caller)
+		default:
+			fn = func(ctx context.Context) error {
+				return caller.Call(ctx, client)
+			}
+		}
+
+		err := errorHandler(ctx, fn)
+
+		var nrErr *NonRetryableError
+		if errors.As(err, &nrErr) {
+			return nrErr.Err
+		}
+
+		return err
 	})
 }
 
-// TODO: Add retry options struct.
-// TODO: Have different handling of calls that are expected to be valid, vs. might be invalid.
+func retryableSendCall(client Client, caller ClientCaller) func(context.Context) error {
+	attempts := 0
+
+	return func(ctx context.Context) error {
+		attempts++
+
+		err := caller.Call(ctx, client)
+
+		switch category := ClassifyError(err); {
+		case category == ErrorCategoryNone:
+			return nil
+		case category == ErrorCategoryAlreadyKnown:
+			return nil
+		case category == ErrorCategoryUnavailable, category == ErrorCategoryRateLimited:
+			return err
+		case category == ErrorCategoryNonceTooLow && attempts > 1 && isSentTransactionKnown(ctx, client, caller):
+			// A previous attempt reached the node despite the error.
+			return nil
+		default:
+			return &NonRetryableError{err}
+		}
+	}
+}
+
+func isSentTransactionKnown(ctx context.Context, client ethereum.TransactionReader, caller ClientCaller) bool {
+	if len(caller.Args()) == 0 {
+		return false
+	}
 
+	tx, ok := caller.Args()[0].(*types.Transaction)
+	if !ok || tx == nil {
+		return false
+	}
+
+	_, _, err := client.TransactionByHash(ctx, tx.Hash())
+	return err == nil
+}
+
+// RetryIfTemporaryError is RetryIfTemporaryErrorWithOptions using the default
+// RetryOptions, with no limit on attempts or duration.
 func RetryIfTemporaryError(unknownError func(context.Context, error) error) func(context.Context, func(context.Context) error) error {
+	return RetryIfTemporaryErrorWithOptions(unknownError, RetryOptions{})
+}
+
+// RetryIfTemporaryErrorWithOptions returns an error handler for
+// NewClientWithRetry that retries temporary errors with exponential back-off.
+//
+// Errors that are not temporary are passed to unknownError, and the call is
+// retried if it returns nil.
+//
+// Sleeping between attempts is interrupted if the context is done, in which
+// case the context error is returned.
+//
+// Calls rejected by an open circuit breaker are not retried, see
+// ErrorCategoryCircuitOpen.
+//
+// Invalid options cause the handler to always return an error.
+func RetryIfTemporaryErrorWithOptions(unknownError func(context.Context, error) error, opts RetryOptions) func(context.Context, func(context.Context) error) error {
+	opts, optsErr := opts.withDefaults()
+
 	return func(ctx context.Context, fn func(context.Context) error) error {
+		if optsErr != nil {
+			return fmt.Errorf("invalid retry options: %w", optsErr)
+		}
+
+		backoff := newRetryBackoff(opts)
+
 		for {
 			err := fn(ctx)
 			if err == nil {
 				return nil
 			}
 
-			var newErr error
+			var nrErr *NonRetryableError
 			var rpcErr rpc.Error
 
-			isTemporary := func() bool {
-				switch {
-				case errors.Is(err, syscall.ECONNRESET):
-				case os.IsTimeout(err):
-				case strings.HasSuffix(err.Error(), ": i/o timeout"):
-				default:
-					return false
-				}
-
-				return true
-			}
-
-			switch {
-			case errors.Is(err, context.Canceled):
-				return err
-			case errors.Is(err, context.DeadlineExceeded):
+			switch category := ClassifyError(err); {
+			case errors.As(err, &nrErr):
+				return nrErr.Err
+			case category == ErrorCategoryCanceled, category == ErrorCategoryCircuitOpen:
 				return err
-			case isTemporary():
+			case category.IsTemporary():
 				// TODO: Use an temporary error handler.
-				time.Sleep(1 * time.Second)
-				continue
 			case errors.As(err, &rpcErr):
-				// TODO: Retry depending on the error.
-				newErr = unknownError(ctx, fmt.Errorf("ethereum client call failed, rpc error: %w", err))
+				if newErr := unknownError(ctx, fmt.Errorf("ethereum client call failed, %s rpc error: %w", category, err)); newErr != nil {
+					return newErr
+				}
 			default:
-				newErr = unknownError(ctx, fmt.Errorf("ethereum client call failed, unknown error: %w", err))
+				if newErr := unknownError(ctx, fmt.Errorf("ethereum client call failed, %s error: %w", category, err)); newErr != nil {
+					return newErr
+				}
 			}
 
-			if newErr != nil {
-				return newErr
+			if err := backoff.wait(ctx, err); err != nil {
+				return err
 			}
-
-			// TODO: Use back-off function.
-			time.Sleep(3 * time.Second)
 		}
 	}
 }
@@ -2,12 +2,17 @@ package ethhelpers_test
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"net"
+	"os"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
 	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
@@ -99,3 +104,308 @@ func TestClientWithRetry_RetryIfTemporaryError(t *testing.T) {
 		})
 	}
 }
+
+func TestClientWithRetry_RetryIfTemporaryErrorWithOptions(t *testing.T) {
+	type testArgs struct {
+		ctx    context.Context
+		client ethhelpers.Client
+		mock   *mock.Mock
+		sleeps func() []time.Duration
+	}
+
+	tests := []struct {
+		name      string
+		opts      ethhelpers.RetryOptions
+		realSleep bool
+		fn        func(*testing.T, testArgs)
+	}{
+		{
+			name: "BlockNumber retries with exponential back-off",
+			opts: ethhelpers.RetryOptions{
+				InitialDelay: 100 * time.Millisecond,
+				MaxDelay:     300 * time.Millisecond,
+			},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Times(4)
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(123), blockNumber)
+				assert.Equal(t, []time.Duration{
+					100 * time.Millisecond,
+					200 * time.Millisecond,
+					300 * time.Millisecond,
+					300 * time.Millisecond,
+				}, args.sleeps())
+			},
+		}, {
+			name: "BlockNumber returns error after max attempts",
+			opts: ethhelpers.RetryOptions{
+				MaxAttempts: 3,
+			},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Times(3)
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, syscall.ECONNRESET)
+				assert.Equal(t, uint64(0), blockNumber)
+				assert.Equal(t, []time.Duration{1 * time.Second, 2 * time.Second}, args.sleeps())
+			},
+		}, {
+			name: "BlockNumber returns error after max duration",
+			opts: ethhelpers.RetryOptions{
+				MaxDuration: 5 * time.Second,
+			},
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Times(3)
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.ErrorIs(t, err, syscall.ECONNRESET)
+				assert.Equal(t, uint64(0), blockNumber)
+				assert.Equal(t, []time.Duration{1 * time.Second, 2 * time.Second}, args.sleeps())
+			},
+		}, {
+			name: "BlockNumber returns context error when canceled while sleeping",
+			opts: ethhelpers.RetryOptions{
+				InitialDelay: time.Hour,
+				MaxDelay:     time.Hour,
+			},
+			realSleep: true,
+			fn: func(t *testing.T, args testArgs) {
+				ctx, cancel := context.WithTimeout(args.ctx, 50*time.Millisecond)
+				defer cancel()
+
+				args.mock.On("BlockNumber", ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+
+				blockNumber, err := args.client.BlockNumber(ctx)
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+				assert.Equal(t, uint64(0), blockNumber)
+			},
+		}, {
+			name: "BlockNumber returns error with invalid options",
+			opts: ethhelpers.RetryOptions{
+				Multiplier: 0.5,
+			},
+			fn: func(t *testing.T, args testArgs) {
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.Error(t, err)
+				assert.Equal(t, uint64(0), blockNumber)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			client := ethtesting.NewClientWithMock()
+
+			var mu sync.Mutex
+			var sleeps []time.Duration
+			now := time.Unix(0, 0)
+
+			opts := test.opts
+			opts.Now = func() time.Time {
+				mu.Lock()
+				defer mu.Unlock()
+				return now
+			}
+			if !test.realSleep {
+				opts.Sleep = func(ctx context.Context, d time.Duration) error {
+					mu.Lock()
+					defer mu.Unlock()
+					sleeps = append(sleeps, d)
+					now = now.Add(d)
+					return nil
+				}
+			}
+
+			test.fn(t, testArgs{
+				ctx,
+				ethhelpers.NewClientWithRetry(client, ethhelpers.RetryIfTemporaryErrorWithOptions(unknownErrorWithAssertFail(t), opts)),
+				client.Mock(),
+				func() []time.Duration {
+					mu.Lock()
+					defer mu.Unlock()
+					return sleeps
+				},
+			})
+
+			client.Mock().AssertExpectations(t)
+		})
+	}
+}
+
+func TestRetryOptions_Delay(t *testing.T) {
+	opts := ethhelpers.RetryOptions{
+		InitialDelay: 10 * time.Millisecond,
+		MaxDelay:     100 * time.Millisecond,
+		Multiplier:   3,
+	}
+
+	assert.Equal(t, time.Duration(0), opts.Delay(0))
+	assert.Equal(t, 10*time.Millisecond, opts.Delay(1))
+	assert.Equal(t, 30*time.Millisecond, opts.Delay(2))
+	assert.Equal(t, 90*time.Millisecond, opts.Delay(3))
+	assert.Equal(t, 100*time.Millisecond, opts.Delay(4))
+}
+
+func TestClientWithRetry_SendTransaction(t *testing.T) {
+	type testArgs struct {
+		ctx    context.Context
+		client ethhelpers.Client
+		mock   *mock.Mock
+	}
+
+	tx := types.NewTransaction(1, common.HexToAddress("0x1234"), big.NewInt(1), 21000, big.NewInt(1), nil)
+	timeoutErr := &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}
+
+	tests := []struct {
+		name string
+		fn   func(*testing.T, testArgs)
+	}{
+		{
+			name: "SendTransaction immediately returns",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("SendTransaction", args.ctx, tx).Return(nil).Once()
+
+				err := args.client.SendTransaction(args.ctx, tx)
+				assert.NoError(t, err)
+			},
+		}, {
+			name: "SendTransaction retries if connection was refused",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("SendTransaction", args.ctx, tx).Return(syscall.ECONNREFUSED).Once()
+				args.mock.On("SendTransaction", args.ctx, tx).Return(nil).Once()
+
+				err := args.client.SendTransaction(args.ctx, tx)
+				assert.NoError(t, err)
+			},
+		}, {
+			name: "SendTransaction does not retry after a timeout",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("SendTransaction", args.ctx, tx).Return(timeoutErr).Once()
+
+				err := args.client.SendTransaction(args.ctx, tx)
+				assert.Same(t, timeoutErr, err)
+			},
+		}, {
+			name: "SendTransaction does not retry after a connection reset",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("SendTransaction", args.ctx, tx).Return(syscall.ECONNRESET).Once()
+
+				err := args.client.SendTransaction(args.ctx, tx)
+				assert.ErrorIs(t, err, syscall.ECONNRESET)
+			},
+		}, {
+			name: "SendTransaction succeeds if transaction is already known",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("SendTransaction", args.ctx, tx).Return(testRPCError{-32000, "already known"}).Once()
+
+				err := args.client.SendTransaction(args.ctx, tx)
+				assert.NoError(t, err)
+			},
+		}, {
+			name: "SendTransaction succeeds if retry returns nonce too low for a known transaction",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("SendTransaction", args.ctx, tx).Return(syscall.ECONNREFUSED).Once()
+				args.mock.On("SendTransaction", args.ctx, tx).Return(testRPCError{-32000, "nonce too low"}).Once()
+				args.mock.On("TransactionByHash", args.ctx, tx.Hash()).Return(tx, true, nil).Once()
+
+				err := args.client.SendTransaction(args.ctx, tx)
+				assert.NoError(t, err)
+			},
+		}, {
+			name: "SendTransaction fails if retry returns nonce too low for an unknown transaction",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("SendTransaction", args.ctx, tx).Return(syscall.ECONNREFUSED).Once()
+				args.mock.On("SendTransaction", args.ctx, tx).Return(testRPCError{-32000, "nonce too low"}).Once()
+				args.mock.On("TransactionByHash", args.ctx, tx.Hash()).Return(nil, false, ethereum.NotFound).Once()
+
+				err := args.client.SendTransaction(args.ctx, tx)
+				assert.EqualError(t, err, "nonce too low")
+			},
+		}, {
+			name: "SendTransaction fails without retry on nonce too low",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("SendTransaction", args.ctx, tx).Return(testRPCError{-32000, "nonce too low"}).Once()
+
+				err := args.client.SendTransaction(args.ctx, tx)
+				assert.EqualError(t, err, "nonce too low")
+			},
+		}, {
+			name: "BlockNumber retries after a timeout",
+			fn: func(t *testing.T, args testArgs) {
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(0), timeoutErr).Once()
+				args.mock.On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(123), blockNumber)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			client := ethtesting.NewClientWithMock()
+
+			opts := ethhelpers.RetryOptions{
+				InitialDelay: time.Millisecond,
+			}
+
+			test.fn(t, testArgs{
+				ctx,
+				ethhelpers.NewClientWithRetry(client, ethhelpers.RetryIfTemporaryErrorWithOptions(unknownErrorWithAssertFail(t), opts)),
+				client.Mock(),
+			})
+
+			client.Mock().AssertExpectations(t)
+		})
+	}
+}
+
+func TestClientWithRetry_WrappedNonRetryableError(t *testing.T) {
+	timeoutErr := &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}
+	wrappedErr := fmt.Errorf("inner handler: %w", &ethhelpers.NonRetryableError{Err: timeoutErr})
+
+	tests := []struct {
+		name         string
+		errorHandler func(context.Context, func(context.Context) error) error
+	}{
+		{
+			name:         "RetryIfTemporaryError",
+			errorHandler: ethhelpers.RetryIfTemporaryErrorWithOptions(unknownErrorWithAssertFail(t), ethhelpers.RetryOptions{InitialDelay: time.Millisecond}),
+		}, {
+			name: "error handler returning the error",
+			errorHandler: func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			client := ethtesting.NewClientWithMock()
+			client.Mock().On("BlockNumber", ctx).Return(uint64(0), wrappedErr).Once()
+
+			_, err := ethhelpers.NewClientWithRetry(client, test.errorHandler).BlockNumber(ctx)
+			assert.Same(t, timeoutErr, err)
+
+			client.Mock().AssertExpectations(t)
+		})
+	}
+}
@@ -9,7 +9,12 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+//go:generate go run ../internal/cmd/genclients -source clients.go -interface Client -handlers client_with_handlers_gen.go -mock ../ethtesting/client_with_mock_gen.go
+
 // Client is an interface that holds the same methods as ethclient.Client.
+//
+// The methods of the handler and mock clients are generated from this
+// interface, run go generate after changing it.
 type Client interface {
 	//
 	// Methods from go-ethereum interfaces:
@@ -49,7 +54,7 @@ type Client interface {
 	// ethereum.PendingContractCaller
 	PendingCallContract(ctx context.Context, call ethereum.CallMsg) ([]byte, error)
 
-	// ethereum.PendingStateEventer (geth only)
+	// ethereum.PendingStateEventer (geth only), see PendingTransactionsSubscriber
 	// SubscribePendingTransactions(ctx context.Context, ch chan<- *types.Transaction) (ethereum.Subscription, error)
 
 	// ethereum.PendingStateReader
@@ -79,7 +84,6 @@ type Client interface {
 
 	CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error)
 	ChainID(ctx context.Context) (*big.Int, error)
-	// TODO: Remove Close?
 	Close()
 	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
 	NetworkID(ctx context.Context) (*big.Int, error)
@@ -87,7 +91,63 @@ type Client interface {
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 }
 
-// TODO: Add client without subcription methods.
+// ReadClient holds the methods of Client that read the chain, excluding
+// subscriptions.
+//
+// Use NewPartialClient to create a Client from a ReadClient.
+type ReadClient interface {
+	BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error)
+	TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error)
+	TransactionByHash(ctx context.Context, txHash common.Hash) (tx *types.Transaction, isPending bool, err error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+
+	PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error)
+	PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error)
+	PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	PendingTransactionCount(ctx context.Context) (uint, error)
+
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error)
+	PendingCallContract(ctx context.Context, call ethereum.CallMsg) ([]byte, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+
+	ChainID(ctx context.Context) (*big.Int, error)
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+}
+
+// SubscribingClient holds the subscription methods of Client.
+type SubscribingClient interface {
+	SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// SendingClient holds the methods of Client that change the chain.
+type SendingClient interface {
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// AdminClient holds the methods of Client that concern the node and the
+// connection to it, rather than the chain.
+type AdminClient interface {
+	Close()
+	NetworkID(ctx context.Context) (*big.Int, error)
+	PeerCount(ctx context.Context) (uint64, error)
+	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
+}
 
 type BlockNumberReader interface {
 	BlockNumber(ctx context.Context) (uint64, error)
@@ -98,6 +158,21 @@ type ChainReaderAndTransactionSender interface {
 	ethereum.ChainReader
 }
 
+type FinalizedBlockNumberReader interface {
+	FinalizedBlockNumber(ctx context.Context) (uint64, error)
+}
+
 type FilterLogsReader interface {
 	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
 }
+
+// PendingTransactionsSubscriber is implemented by clients that can subscribe
+// to pending transactions, which is not part of Client as it is only
+// supported by some nodes.
+type PendingTransactionsSubscriber interface {
+	SubscribePendingTransactions(ctx context.Context, ch chan<- *types.Transaction) (ethereum.Subscription, error)
+}
+
+type HeaderByNumberReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
@@ -96,3 +96,19 @@ func RPCClientFromContext(ctx context.Context) (*rpc.Client, bool) {
 	c, ok := ctx.Value(rpcClientContextKey{}).(*rpc.Client)
 	return c, ok
 }
+
+type correlationIDContextKey struct{}
+
+// ContextWithCorrelationID creates a new context which contains a correlation
+// ID, which is attached to log entries of calls made with the context.
+//
+// The context will return the ID when calling CorrelationIDFromContext.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey{}, id)
+}
+
+// CorrelationIDFromContext retrieves a correlation ID from the context, if any.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDContextKey{}).(string)
+	return id, ok
+}
@@ -195,3 +195,36 @@ func TestContext_ConfigFromContext(t *testing.T) {
 		test.fn(fmt.Sprintf("%d: %s", idx, test.name))
 	}
 }
+
+func TestContext_CorrelationIDFromContext(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name string
+		fn   func(string)
+	}{
+		{
+			"empty context",
+			func(name string) {
+				id, ok := ethhelpers.CorrelationIDFromContext(context.Background())
+				assert.Equal("", id, name)
+				assert.False(ok, name)
+			},
+		}, {
+			"with correlation id",
+			func(name string) {
+				ctx := ethhelpers.ContextWithCorrelationID(context.Background(), "test")
+
+				id, ok := ethhelpers.CorrelationIDFromContext(ctx)
+				assert.Equal("test", id, name)
+				assert.True(ok, name)
+			},
+		},
+	}
+
+	t.Parallel()
+
+	for idx, test := range tests {
+		test.fn(fmt.Sprintf("%d: %s", idx, test.name))
+	}
+}
@@ -0,0 +1,228 @@
+package ethhelpers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrorCategory is the category of an error returned by a client call, as
+// returned by ClassifyError.
+type ErrorCategory int
+
+const (
+	ErrorCategoryNone ErrorCategory = iota
+	ErrorCategoryUnknown
+
+	// ErrorCategoryCanceled is used for context cancellation and deadlines.
+	ErrorCategoryCanceled
+
+	// ErrorCategoryTemporary is used for transport errors where the request
+	// might have been received by the node, e.g. timeouts and connection
+	// resets.
+	ErrorCategoryTemporary
+
+	// ErrorCategoryUnavailable is used for transport errors where the request
+	// was never received by the node, e.g. connection refused or failed DNS
+	// lookups.
+	ErrorCategoryUnavailable
+
+	// ErrorCategoryRateLimited is used when the provider rejected the request
+	// due to rate limits.
+	ErrorCategoryRateLimited
+
+	// ErrorCategoryCircuitOpen is used when the call was rejected by an open
+	// circuit breaker without being sent. It is not temporary, as retrying
+	// would defeat the circuit breaker failing fast.
+	ErrorCategoryCircuitOpen
+
+	ErrorCategoryNotFound
+	ErrorCategoryHeaderNotFound
+	ErrorCategoryExecutionReverted
+
+	// ErrorCategoryNotSupported is used when the client or node does not
+	// support the method.
+	ErrorCategoryNotSupported
+
+	// ErrorCategoryQueryTooLarge is used when the provider rejected a log
+	// query because the block range or the number of results is too large.
+	ErrorCategoryQueryTooLarge
+
+	// ErrorCategoryFilterNotFound is used when the node does not know the
+	// filter ID, usually because the filter has expired.
+	ErrorCategoryFilterNotFound
+
+	// Transaction pool errors:
+
+	ErrorCategoryAlreadyKnown
+	ErrorCategoryNonceTooLow
+	ErrorCategoryReplacementUnderpriced
+	ErrorCategoryInsufficientFunds
+)
+
+var errorCategoryNames = map[ErrorCategory]string{
+	ErrorCategoryNone:                   "none",
+	ErrorCategoryUnknown:                "unknown",
+	ErrorCategoryCanceled:               "canceled",
+	ErrorCategoryTemporary:              "temporary",
+	ErrorCategoryUnavailable:            "unavailable",
+	ErrorCategoryRateLimited:            "rate-limited",
+	ErrorCategoryCircuitOpen:            "circuit-open",
+	ErrorCategoryNotFound:               "not-found",
+	ErrorCategoryHeaderNotFound:         "header-not-found",
+	ErrorCategoryExecutionReverted:      "execution-reverted",
+	ErrorCategoryNotSupported:           "not-supported",
+	ErrorCategoryQueryTooLarge:          "query-too-large",
+	ErrorCategoryFilterNotFound:         "filter-not-found",
+	ErrorCategoryAlreadyKnown:           "already-known",
+	ErrorCategoryNonceTooLow:            "nonce-too-low",
+	ErrorCategoryReplacementUnderpriced: "replacement-underpriced",
+	ErrorCategoryInsufficientFunds:      "insufficient-funds",
+}
+
+func (c ErrorCategory) String() string {
+	if name, ok := errorCategoryNames[c]; ok {
+		return name
+	}
+
+	return "invalid"
+}
+
+// IsTemporary returns true if the call may succeed if retried.
+func (c ErrorCategory) IsTemporary() bool {
+	switch c {
+	case ErrorCategoryTemporary, ErrorCategoryUnavailable, ErrorCategoryRateLimited:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	rpcErrorCodeExecutionReverted = 3
+	rpcErrorCodeMethodNotFound    = -32601
+	rpcErrorCodeLimitExceeded     = -32005
+)
+
+// ClassifyError returns the category of an error returned by a client call.
+//
+// Errors are recognized by type, rpc.Error codes and the messages used by
+// common node implementations. Messages are matched case-insensitively as
+// the same error may be reported differently by other nodes and providers.
+func ClassifyError(err error) ErrorCategory {
+	if err == nil {
+		return ErrorCategoryNone
+	}
+
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	var httpErr rpc.HTTPError
+	var rpcErr rpc.Error
+
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return ErrorCategoryCanceled
+	case errors.Is(err, ethereum.NotFound):
+		return ErrorCategoryNotFound
+	case errors.Is(err, ErrNotSupported):
+		return ErrorCategoryNotSupported
+
+	case errors.Is(err, ErrCircuitOpen):
+		return ErrorCategoryCircuitOpen
+
+	case errors.Is(err, syscall.ECONNREFUSED), errors.As(err, &dnsErr):
+		return ErrorCategoryUnavailable
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		return ErrorCategoryUnavailable
+
+	case errors.As(err, &httpErr):
+		switch {
+		case httpErr.StatusCode == http.StatusTooManyRequests:
+			return ErrorCategoryRateLimited
+		case httpErr.StatusCode >= 500:
+			return ErrorCategoryTemporary
+		}
+
+	case errors.As(err, &rpcErr):
+		switch rpcErr.ErrorCode() {
+		case rpcErrorCodeExecutionReverted:
+			return ErrorCategoryExecutionReverted
+		case rpcErrorCodeMethodNotFound:
+			return ErrorCategoryNotSupported
+		case rpcErrorCodeLimitExceeded:
+			// Also used by some providers for log queries that are too large.
+			if isQueryTooLargeMessage(strings.ToLower(err.Error())) {
+				return ErrorCategoryQueryTooLarge
+			}
+
+			return ErrorCategoryRateLimited
+		}
+
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE):
+		return ErrorCategoryTemporary
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorCategoryTemporary
+	case os.IsTimeout(err):
+		return ErrorCategoryTemporary
+	}
+
+	return classifyErrorMessage(strings.ToLower(err.Error()))
+}
+
+func classifyErrorMessage(msg string) ErrorCategory {
+	switch {
+	case strings.HasSuffix(msg, ": i/o timeout"):
+		return ErrorCategoryTemporary
+	case msg == "request failed or timed out":
+		return ErrorCategoryTemporary
+
+	case strings.Contains(msg, "already known"), strings.HasPrefix(msg, "known transaction"):
+		return ErrorCategoryAlreadyKnown
+	case strings.Contains(msg, "nonce too low"):
+		return ErrorCategoryNonceTooLow
+	case strings.Contains(msg, "replacement transaction underpriced"):
+		return ErrorCategoryReplacementUnderpriced
+	case strings.Contains(msg, "insufficient funds"):
+		return ErrorCategoryInsufficientFunds
+	case strings.HasPrefix(msg, "execution reverted"):
+		return ErrorCategoryExecutionReverted
+
+	case strings.Contains(msg, "header not found"), strings.Contains(msg, "unknown block"):
+		return ErrorCategoryHeaderNotFound
+	case strings.Contains(msg, "filter not found"):
+		return ErrorCategoryFilterNotFound
+	case msg == "not found":
+		return ErrorCategoryNotFound
+
+	case isQueryTooLargeMessage(msg):
+		return ErrorCategoryQueryTooLarge
+	case strings.Contains(msg, "rate limit"), strings.Contains(msg, "too many requests"):
+		return ErrorCategoryRateLimited
+
+	default:
+		return ErrorCategoryUnknown
+	}
+}
+
+func isQueryTooLargeMessage(msg string) bool {
+	switch {
+	case strings.Contains(msg, "query returned more than"):
+		return true
+	case strings.Contains(msg, "block range too large"), strings.Contains(msg, "block range is too large"):
+		return true
+	case strings.Contains(msg, "block range is too wide"), strings.Contains(msg, "exceed maximum block range"):
+		return true
+	case strings.Contains(msg, "log response size exceeded"):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,86 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected ethhelpers.ErrorCategory
+	}{
+		{"nil", nil, ethhelpers.ErrorCategoryNone},
+		{"unknown", errors.New("something failed"), ethhelpers.ErrorCategoryUnknown},
+
+		{"context canceled", context.Canceled, ethhelpers.ErrorCategoryCanceled},
+		{"context deadline exceeded", fmt.Errorf("wrapped: %w", context.DeadlineExceeded), ethhelpers.ErrorCategoryCanceled},
+
+		{"connection reset", syscall.ECONNRESET, ethhelpers.ErrorCategoryTemporary},
+		{"net timeout", &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, ethhelpers.ErrorCategoryTemporary},
+		{"i/o timeout message", errors.New("post \"http://localhost\": i/o timeout"), ethhelpers.ErrorCategoryTemporary},
+		{"request failed or timed out", testRPCError{-32000, "request failed or timed out"}, ethhelpers.ErrorCategoryTemporary},
+		{"http 503", rpc.HTTPError{StatusCode: 503, Status: "503 Service Unavailable"}, ethhelpers.ErrorCategoryTemporary},
+
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, ethhelpers.ErrorCategoryUnavailable},
+		{"dns error", &net.DNSError{Err: "no such host", Name: "localhost"}, ethhelpers.ErrorCategoryUnavailable},
+		{"circuit open", &ethhelpers.CircuitOpenError{Method: "BlockNumber"}, ethhelpers.ErrorCategoryCircuitOpen},
+
+		{"http 429", rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"}, ethhelpers.ErrorCategoryRateLimited},
+		{"rpc limit exceeded", testRPCError{-32005, "limit exceeded"}, ethhelpers.ErrorCategoryRateLimited},
+		{"rate limit message", errors.New("Your app has exceeded its compute units per second capacity, rate limit reached"), ethhelpers.ErrorCategoryRateLimited},
+
+		{"ethereum not found", ethereum.NotFound, ethhelpers.ErrorCategoryNotFound},
+		{"not found message", testRPCError{-32000, "not found"}, ethhelpers.ErrorCategoryNotFound},
+		{"header not found", testRPCError{-32000, "header not found"}, ethhelpers.ErrorCategoryHeaderNotFound},
+		{"unknown block", testRPCError{-32000, "unknown block"}, ethhelpers.ErrorCategoryHeaderNotFound},
+		{"filter not found", testRPCError{-32000, "filter not found"}, ethhelpers.ErrorCategoryFilterNotFound},
+
+		{"execution reverted code", testRPCError{3, "execution reverted: reason"}, ethhelpers.ErrorCategoryExecutionReverted},
+		{"execution reverted message", errors.New("execution reverted"), ethhelpers.ErrorCategoryExecutionReverted},
+
+		{"not supported", fmt.Errorf("FeeHistory: %w", ethhelpers.ErrNotSupported), ethhelpers.ErrorCategoryNotSupported},
+		{"rpc method not found", testRPCError{-32601, "the method eth_feeHistory does not exist/is not available"}, ethhelpers.ErrorCategoryNotSupported},
+
+		{"query returned more than", testRPCError{-32005, "query returned more than 10000 results"}, ethhelpers.ErrorCategoryQueryTooLarge},
+		{"block range too large", testRPCError{-32000, "block range too large"}, ethhelpers.ErrorCategoryQueryTooLarge},
+		{"exceed maximum block range", testRPCError{-32000, "exceed maximum block range: 5000"}, ethhelpers.ErrorCategoryQueryTooLarge},
+		{"log response size exceeded", errors.New("Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range"), ethhelpers.ErrorCategoryQueryTooLarge},
+
+		{"already known", testRPCError{-32000, "already known"}, ethhelpers.ErrorCategoryAlreadyKnown},
+		{"known transaction", testRPCError{-32000, "known transaction: 0x1234"}, ethhelpers.ErrorCategoryAlreadyKnown},
+		{"nonce too low", testRPCError{-32000, "nonce too low"}, ethhelpers.ErrorCategoryNonceTooLow},
+		{"replacement underpriced", testRPCError{-32000, "replacement transaction underpriced"}, ethhelpers.ErrorCategoryReplacementUnderpriced},
+		{"insufficient funds", testRPCError{-32000, "insufficient funds for gas * price + value"}, ethhelpers.ErrorCategoryInsufficientFunds},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ethhelpers.ClassifyError(test.err), "category %s", ethhelpers.ClassifyError(test.err))
+		})
+	}
+}
+
+func TestErrorCategory_IsTemporary(t *testing.T) {
+	assert.True(t, ethhelpers.ErrorCategoryTemporary.IsTemporary())
+	assert.True(t, ethhelpers.ErrorCategoryUnavailable.IsTemporary())
+	assert.True(t, ethhelpers.ErrorCategoryRateLimited.IsTemporary())
+
+	assert.False(t, ethhelpers.ErrorCategoryNone.IsTemporary())
+	assert.False(t, ethhelpers.ErrorCategoryUnknown.IsTemporary())
+	assert.False(t, ethhelpers.ErrorCategoryCanceled.IsTemporary())
+	assert.False(t, ethhelpers.ErrorCategoryNonceTooLow.IsTemporary())
+}
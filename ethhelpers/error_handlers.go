@@ -4,10 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"syscall"
 
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -21,36 +18,26 @@ func DefaultErrorHandlerWithMessages(msgHandler func(txHash common.Hash, msg str
 	return func(txHash common.Hash, err error) error {
 		var rpcErr rpc.Error
 
-		switch {
-		case errors.As(err, &rpcErr):
-			// TODO: We should have optional permissive error handling code for
-			// temporary errors.
-			if err.Error() == "request failed or timed out" {
-				msgHandler(txHash, fmt.Sprintf("waiting for transaction receipt, temporary error : %d : %v", rpcErr.ErrorCode(), rpcErr))
-				break
-			}
-
-			msgHandler(txHash, fmt.Sprintf("waiting for transaction receipt, unknown rpc error : %d : %v", rpcErr.ErrorCode(), rpcErr))
-			return err
-
+		switch category := ClassifyError(err); {
 		case errors.Is(err, context.Canceled):
 			msgHandler(txHash, fmt.Sprintf("waiting for transaction receipt, context canceled"))
 
 		case errors.Is(err, context.DeadlineExceeded):
 			msgHandler(txHash, fmt.Sprintf("waiting for transaction receipt, context deadline"))
 
-		case errors.Is(err, ethereum.NotFound):
+		case category == ErrorCategoryNotFound:
 			msgHandler(txHash, fmt.Sprintf("waiting for transaction receipt, not found"))
 
-		case errors.Is(err, syscall.ECONNRESET):
-			msgHandler(txHash, fmt.Sprintf("waiting for transaction receipt, connection reset by peer"))
+		case category.IsTemporary():
+			msgHandler(txHash, fmt.Sprintf("waiting for transaction receipt, %s error : %v", category, err))
 
-		case os.IsTimeout(err):
-			msgHandler(txHash, fmt.Sprintf("waiting for transaction receipt, timeout"))
+		case errors.As(err, &rpcErr):
+			msgHandler(txHash, fmt.Sprintf("waiting for transaction receipt, %s rpc error : %d : %v", category, rpcErr.ErrorCode(), rpcErr))
+			return err
 
 		// TODO: Check non-temporary errors.
 		default:
-			msgHandler(txHash, fmt.Sprintf("waiting for transaction receipt, unknown error : %v", err))
+			msgHandler(txHash, fmt.Sprintf("waiting for transaction receipt, %s error : %v", category, err))
 			return err
 		}
 
@@ -0,0 +1,53 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultErrorHandlerWithMessages(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		expectedErr bool
+	}{
+		{"context canceled", context.Canceled, false},
+		{"not found", ethereum.NotFound, false},
+		{"connection reset", syscall.ECONNRESET, false},
+		{"request failed or timed out", testRPCError{-32000, "request failed or timed out"}, false},
+		{"rpc error", testRPCError{-32000, "invalid argument"}, true},
+		{"unknown error", errors.New("unknown"), true},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			txHash := common.HexToHash("0x1234")
+
+			var messages []string
+
+			handler := ethhelpers.DefaultErrorHandlerWithMessages(func(h common.Hash, msg string) {
+				assert.Equal(t, txHash, h)
+				messages = append(messages, msg)
+			})
+
+			err := handler(txHash, test.err)
+
+			if test.expectedErr {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Len(t, messages, 1)
+		})
+	}
+}
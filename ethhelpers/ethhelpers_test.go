@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
@@ -48,6 +49,19 @@ func newDefaultSimulatedBackendWithCallableContract(t *testing.T) (*ethtesting.S
 	return sim, contract, cancel
 }
 
+// testSignedTransaction returns a transaction with the nonce signed by
+// ethtesting.MockPrivateKey1.
+func testSignedTransaction(nonce uint64) *types.Transaction {
+	to := crypto.PubkeyToAddress(ethtesting.MockPrivateKey2.PublicKey)
+
+	tx, err := types.SignTx(types.NewTransaction(nonce, to, big.NewInt(1), 21000, big.NewInt(1), nil), types.HomesteadSigner{}, ethtesting.MockPrivateKey1)
+	if err != nil {
+		panic(err)
+	}
+
+	return tx
+}
+
 func readLogFromChan(ch <-chan types.Log) (types.Log, bool) {
 	select {
 	case r, ok := <-ch:
@@ -153,3 +167,16 @@ func unknownErrorWithAssertFail(t *testing.T) func(context.Context, error) error
 		return context.Canceled
 	}
 }
+
+type testRPCError struct {
+	code    int
+	message string
+}
+
+func (e testRPCError) Error() string {
+	return e.message
+}
+
+func (e testRPCError) ErrorCode() int {
+	return e.code
+}
@@ -0,0 +1,310 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+//go:generate go run ../internal/cmd/genclients -source extended_client.go -interface ExtendedClient -handlers "" -mock ../ethtesting/extended_client_with_mock_gen.go -mock-type extendedClientWithMock
+
+// ExtendedClient is a Client with typed wrappers for RPC methods that are not
+// exposed by ethclient.Client.
+//
+// The methods of the mock client are generated from this interface, run go
+// generate after changing it.
+type ExtendedClient interface {
+	Client
+
+	// BlockReceipts returns the receipts of all transactions in a block using
+	// eth_getBlockReceipts.
+	BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error)
+
+	// GetProof returns the account and storage values of an account, including
+	// the Merkle proofs, using eth_getProof.
+	GetProof(ctx context.Context, account common.Address, keys []string, blockNumber *big.Int) (*AccountResult, error)
+
+	// CreateAccessList returns the access list and gas used by a call using
+	// eth_createAccessList.
+	CreateAccessList(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*AccessListResult, error)
+
+	// CallContractWithOverrides executes a message call with the account
+	// state replaced by overrides using eth_call.
+	CallContractWithOverrides(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int, overrides map[common.Address]OverrideAccount) ([]byte, error)
+
+	// MaxPriorityFeePerGas returns the suggested gas tip cap using
+	// eth_maxPriorityFeePerGas.
+	MaxPriorityFeePerGas(ctx context.Context) (*big.Int, error)
+
+	// FinalizedBlockNumber returns the number of the latest finalized block
+	// using eth_getBlockByNumber with the finalized tag.
+	FinalizedBlockNumber(ctx context.Context) (uint64, error)
+
+	// ClientVersion returns the version of the node using web3_clientVersion.
+	ClientVersion(ctx context.Context) (string, error)
+
+	// TxPoolContent returns the pending and queued transactions using
+	// txpool_content.
+	TxPoolContent(ctx context.Context) (*TxPoolContent, error)
+
+	// TxPoolStatus returns the number of pending and queued transactions using
+	// txpool_status.
+	TxPoolStatus(ctx context.Context) (*TxPoolStatus, error)
+
+	// TraceTransaction returns the call trace of a transaction using
+	// debug_traceTransaction with the callTracer.
+	TraceTransaction(ctx context.Context, txHash common.Hash) (*CallFrame, error)
+
+	// TraceCall returns the call trace of a message call using
+	// debug_traceCall with the callTracer.
+	TraceCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*CallFrame, error)
+}
+
+// AccountResult is the result of eth_getProof.
+type AccountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []string        `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageResult `json:"storageProof"`
+}
+
+// StorageResult is the proof of a storage key in an AccountResult.
+type StorageResult struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []string     `json:"proof"`
+}
+
+// AccessListResult is the result of eth_createAccessList.
+//
+// Error is the error of the call when executed with the access list, if any.
+type AccessListResult struct {
+	AccessList types.AccessList `json:"accessList"`
+	GasUsed    hexutil.Uint64   `json:"gasUsed"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// OverrideAccount replaces the state of an account in a call with overrides.
+//
+// State replaces the whole storage of the account, while StateDiff replaces
+// only the given slots.
+type OverrideAccount struct {
+	Nonce     *hexutil.Uint64             `json:"nonce,omitempty"`
+	Code      hexutil.Bytes               `json:"code,omitempty"`
+	Balance   *hexutil.Big                `json:"balance,omitempty"`
+	State     map[common.Hash]common.Hash `json:"state,omitempty"`
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff,omitempty"`
+}
+
+// TxPoolContent is the result of txpool_content, with transactions indexed by
+// sender and nonce.
+type TxPoolContent struct {
+	Pending map[common.Address]map[string]*types.Transaction `json:"pending"`
+	Queued  map[common.Address]map[string]*types.Transaction `json:"queued"`
+}
+
+// TxPoolStatus is the result of txpool_status.
+type TxPoolStatus struct {
+	Pending hexutil.Uint `json:"pending"`
+	Queued  hexutil.Uint `json:"queued"`
+}
+
+// CallFrame is a call in the result of the callTracer.
+type CallFrame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []CallFrame     `json:"calls,omitempty"`
+}
+
+var callTracerConfig = map[string]interface{}{
+	"tracer": "callTracer",
+}
+
+type extendedClient struct {
+	*ethclient.Client
+
+	rpcClient *rpc.Client
+}
+
+// NewExtendedClient creates an ExtendedClient that makes calls with rpcClient.
+func NewExtendedClient(rpcClient *rpc.Client) (ExtendedClient, error) {
+	if rpcClient == nil {
+		return nil, fmt.Errorf("rpcClient must not be nil")
+	}
+
+	return &extendedClient{
+		Client:    ethclient.NewClient(rpcClient),
+		rpcClient: rpcClient,
+	}, nil
+}
+
+// ExtendedClientFromContext creates an ExtendedClient from the RPC client in
+// the context, if any.
+func ExtendedClientFromContext(ctx context.Context) (ExtendedClient, bool) {
+	rpcClient, ok := RPCClientFromContext(ctx)
+	if !ok || rpcClient == nil {
+		return nil, false
+	}
+
+	return &extendedClient{
+		Client:    ethclient.NewClient(rpcClient),
+		rpcClient: rpcClient,
+	}, true
+}
+
+func (c *extendedClient) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	var r []*types.Receipt
+
+	if err := c.rpcClient.CallContext(ctx, &r, "eth_getBlockReceipts", blockNrOrHash); err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return r, nil
+}
+
+func (c *extendedClient) GetProof(ctx context.Context, account common.Address, keys []string, blockNumber *big.Int) (*AccountResult, error) {
+	if keys == nil {
+		keys = []string{}
+	}
+
+	var r *AccountResult
+
+	if err := c.rpcClient.CallContext(ctx, &r, "eth_getProof", account, keys, toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return r, nil
+}
+
+func (c *extendedClient) CreateAccessList(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*AccessListResult, error) {
+	var r *AccessListResult
+
+	if err := c.rpcClient.CallContext(ctx, &r, "eth_createAccessList", toCallArg(msg), toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return r, nil
+}
+
+func (c *extendedClient) CallContractWithOverrides(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int, overrides map[common.Address]OverrideAccount) ([]byte, error) {
+	args := []interface{}{toCallArg(msg), toBlockNumArg(blockNumber)}
+
+	// Not all nodes accept a third argument, so it is only sent if needed.
+	if len(overrides) != 0 {
+		args = append(args, overrides)
+	}
+
+	var r hexutil.Bytes
+
+	if err := c.rpcClient.CallContext(ctx, &r, "eth_call", args...); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (c *extendedClient) MaxPriorityFeePerGas(ctx context.Context) (*big.Int, error) {
+	var r hexutil.Big
+
+	if err := c.rpcClient.CallContext(ctx, &r, "eth_maxPriorityFeePerGas"); err != nil {
+		return nil, err
+	}
+
+	return (*big.Int)(&r), nil
+}
+
+func (c *extendedClient) FinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	var r *types.Header
+
+	if err := c.rpcClient.CallContext(ctx, &r, "eth_getBlockByNumber", "finalized", false); err != nil {
+		return 0, err
+	}
+	if r == nil {
+		return 0, ethereum.NotFound
+	}
+
+	return r.Number.Uint64(), nil
+}
+
+func (c *extendedClient) ClientVersion(ctx context.Context) (string, error) {
+	var r string
+
+	if err := c.rpcClient.CallContext(ctx, &r, "web3_clientVersion"); err != nil {
+		return "", err
+	}
+
+	return r, nil
+}
+
+func (c *extendedClient) TxPoolContent(ctx context.Context) (*TxPoolContent, error) {
+	var r TxPoolContent
+
+	if err := c.rpcClient.CallContext(ctx, &r, "txpool_content"); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+func (c *extendedClient) TxPoolStatus(ctx context.Context) (*TxPoolStatus, error) {
+	var r TxPoolStatus
+
+	if err := c.rpcClient.CallContext(ctx, &r, "txpool_status"); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+func (c *extendedClient) TraceTransaction(ctx context.Context, txHash common.Hash) (*CallFrame, error) {
+	var r *CallFrame
+
+	if err := c.rpcClient.CallContext(ctx, &r, "debug_traceTransaction", txHash, callTracerConfig); err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return r, nil
+}
+
+func (c *extendedClient) TraceCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*CallFrame, error) {
+	var r *CallFrame
+
+	if err := c.rpcClient.CallContext(ctx, &r, "debug_traceCall", toCallArg(msg), toBlockNumArg(blockNumber), callTracerConfig); err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return r, nil
+}
@@ -0,0 +1,328 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testExtendedAddress = common.HexToAddress("0x0101")
+	testExtendedHash    = common.HexToHash("0x01")
+)
+
+type testExtendedEthService struct{}
+
+func (s *testExtendedEthService) GetBlockReceipts(blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	if hash, ok := blockNrOrHash.Hash(); !ok || hash != testExtendedHash {
+		return nil, nil
+	}
+
+	return []*types.Receipt{{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      testExtendedHash,
+		BlockHash:   testExtendedHash,
+		BlockNumber: big.NewInt(100),
+		Logs:        []*types.Log{},
+	}}, nil
+}
+
+func (s *testExtendedEthService) GetProof(account common.Address, keys []string, blockNr rpc.BlockNumber) (*ethhelpers.AccountResult, error) {
+	r := &ethhelpers.AccountResult{
+		Address:      account,
+		AccountProof: []string{"0xaa"},
+		Balance:      (*hexutil.Big)(big.NewInt(1)),
+		Nonce:        2,
+		StorageProof: []ethhelpers.StorageResult{},
+	}
+
+	for _, key := range keys {
+		r.StorageProof = append(r.StorageProof, ethhelpers.StorageResult{
+			Key:   key,
+			Value: (*hexutil.Big)(big.NewInt(3)),
+			Proof: []string{"0xbb"},
+		})
+	}
+
+	return r, nil
+}
+
+func (s *testExtendedEthService) CreateAccessList(args map[string]interface{}, blockNr rpc.BlockNumber) (*ethhelpers.AccessListResult, error) {
+	return &ethhelpers.AccessListResult{
+		AccessList: types.AccessList{{
+			Address:     testExtendedAddress,
+			StorageKeys: []common.Hash{testExtendedHash},
+		}},
+		GasUsed: 21000,
+	}, nil
+}
+
+func (s *testExtendedEthService) Call(args map[string]interface{}, blockNr rpc.BlockNumber, overrides *map[common.Address]ethhelpers.OverrideAccount) (hexutil.Bytes, error) {
+	if overrides == nil {
+		return hexutil.Bytes{0x01}, nil
+	}
+
+	account, ok := (*overrides)[testExtendedAddress]
+	if !ok {
+		return nil, fmt.Errorf("missing override")
+	}
+
+	return hexutil.Bytes(account.Code), nil
+}
+
+func (s *testExtendedEthService) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	if number != rpc.FinalizedBlockNumber {
+		return nil, fmt.Errorf("unexpected block number: %d", number)
+	}
+
+	return &types.Header{Number: big.NewInt(90), Difficulty: big.NewInt(0)}, nil
+}
+
+func (s *testExtendedEthService) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1000))
+}
+
+type testExtendedWeb3Service struct{}
+
+func (s *testExtendedWeb3Service) ClientVersion() string {
+	return "Geth/v1.10.25"
+}
+
+type testExtendedTxPoolService struct{}
+
+func (s *testExtendedTxPoolService) Content() map[string]map[string]map[string]*types.Transaction {
+	return map[string]map[string]map[string]*types.Transaction{
+		"pending": {
+			testExtendedAddress.Hex(): {
+				"1": testSignedTransaction(1),
+			},
+		},
+		"queued": {},
+	}
+}
+
+func (s *testExtendedTxPoolService) Status() map[string]hexutil.Uint {
+	return map[string]hexutil.Uint{
+		"pending": 1,
+		"queued":  2,
+	}
+}
+
+type testExtendedDebugService struct{}
+
+func (s *testExtendedDebugService) TraceTransaction(hash common.Hash, config map[string]interface{}) (*ethhelpers.CallFrame, error) {
+	if config["tracer"] != "callTracer" {
+		return nil, fmt.Errorf("unexpected tracer: %v", config["tracer"])
+	}
+	if hash != testExtendedHash {
+		return nil, fmt.Errorf("transaction %s not found", hash)
+	}
+
+	return testExtendedCallFrame(), nil
+}
+
+func (s *testExtendedDebugService) TraceCall(args map[string]interface{}, blockNr rpc.BlockNumber, config map[string]interface{}) (*ethhelpers.CallFrame, error) {
+	if config["tracer"] != "callTracer" {
+		return nil, fmt.Errorf("unexpected tracer: %v", config["tracer"])
+	}
+
+	return testExtendedCallFrame(), nil
+}
+
+func testExtendedCallFrame() *ethhelpers.CallFrame {
+	return &ethhelpers.CallFrame{
+		Type:    "CALL",
+		From:    testExtendedAddress,
+		To:      &testExtendedAddress,
+		Value:   (*hexutil.Big)(big.NewInt(1)),
+		Gas:     50000,
+		GasUsed: 21000,
+		Input:   hexutil.Bytes{},
+		Calls: []ethhelpers.CallFrame{{
+			Type:    "STATICCALL",
+			From:    testExtendedAddress,
+			To:      &testExtendedAddress,
+			Gas:     1000,
+			GasUsed: 100,
+			Input:   hexutil.Bytes{0x01},
+			Output:  hexutil.Bytes{0x02},
+		}},
+	}
+}
+
+func TestExtendedClient(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(*testing.T, context.Context, ethhelpers.ExtendedClient)
+	}{
+		{
+			name: "BlockReceipts",
+			fn: func(t *testing.T, ctx context.Context, client ethhelpers.ExtendedClient) {
+				r, err := client.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(testExtendedHash, false))
+				if assert.NoError(t, err) && assert.Len(t, r, 1) {
+					assert.Equal(t, testExtendedHash, r[0].TxHash)
+					assert.Equal(t, big.NewInt(100), r[0].BlockNumber)
+				}
+
+				r, err = client.BlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+				assert.ErrorIs(t, err, ethereum.NotFound)
+				assert.Nil(t, r)
+			},
+		}, {
+			name: "GetProof",
+			fn: func(t *testing.T, ctx context.Context, client ethhelpers.ExtendedClient) {
+				r, err := client.GetProof(ctx, testExtendedAddress, []string{"0x01"}, big.NewInt(10))
+				if assert.NoError(t, err) {
+					assert.Equal(t, testExtendedAddress, r.Address)
+					assert.Equal(t, big.NewInt(1), r.Balance.ToInt())
+					assert.Equal(t, hexutil.Uint64(2), r.Nonce)
+					assert.Equal(t, []ethhelpers.StorageResult{{
+						Key:   "0x01",
+						Value: (*hexutil.Big)(big.NewInt(3)),
+						Proof: []string{"0xbb"},
+					}}, r.StorageProof)
+				}
+
+				r, err = client.GetProof(ctx, testExtendedAddress, nil, nil)
+				if assert.NoError(t, err) {
+					assert.Empty(t, r.StorageProof)
+				}
+			},
+		}, {
+			name: "CreateAccessList",
+			fn: func(t *testing.T, ctx context.Context, client ethhelpers.ExtendedClient) {
+				r, err := client.CreateAccessList(ctx, ethereum.CallMsg{To: &testExtendedAddress}, nil)
+				if assert.NoError(t, err) {
+					assert.Equal(t, types.AccessList{{Address: testExtendedAddress, StorageKeys: []common.Hash{testExtendedHash}}}, r.AccessList)
+					assert.Equal(t, hexutil.Uint64(21000), r.GasUsed)
+					assert.Empty(t, r.Error)
+				}
+			},
+		}, {
+			name: "CallContractWithOverrides",
+			fn: func(t *testing.T, ctx context.Context, client ethhelpers.ExtendedClient) {
+				r, err := client.CallContractWithOverrides(ctx, ethereum.CallMsg{To: &testExtendedAddress}, nil, map[common.Address]ethhelpers.OverrideAccount{
+					testExtendedAddress: {Code: hexutil.Bytes{0x60, 0x00}},
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, []byte{0x60, 0x00}, r)
+
+				r, err = client.CallContractWithOverrides(ctx, ethereum.CallMsg{To: &testExtendedAddress}, nil, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, []byte{0x01}, r)
+			},
+		}, {
+			name: "MaxPriorityFeePerGas",
+			fn: func(t *testing.T, ctx context.Context, client ethhelpers.ExtendedClient) {
+				r, err := client.MaxPriorityFeePerGas(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, big.NewInt(1000), r)
+			},
+		}, {
+			name: "FinalizedBlockNumber",
+			fn: func(t *testing.T, ctx context.Context, client ethhelpers.ExtendedClient) {
+				r, err := client.FinalizedBlockNumber(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(90), r)
+			},
+		}, {
+			name: "ClientVersion",
+			fn: func(t *testing.T, ctx context.Context, client ethhelpers.ExtendedClient) {
+				r, err := client.ClientVersion(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, "Geth/v1.10.25", r)
+			},
+		}, {
+			name: "TxPoolContent",
+			fn: func(t *testing.T, ctx context.Context, client ethhelpers.ExtendedClient) {
+				r, err := client.TxPoolContent(ctx)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				if tx := r.Pending[testExtendedAddress]["1"]; assert.NotNil(t, tx) {
+					assert.Equal(t, testSignedTransaction(1).Hash(), tx.Hash())
+				}
+				assert.Empty(t, r.Queued)
+			},
+		}, {
+			name: "TxPoolStatus",
+			fn: func(t *testing.T, ctx context.Context, client ethhelpers.ExtendedClient) {
+				r, err := client.TxPoolStatus(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, &ethhelpers.TxPoolStatus{Pending: 1, Queued: 2}, r)
+			},
+		}, {
+			name: "TraceTransaction",
+			fn: func(t *testing.T, ctx context.Context, client ethhelpers.ExtendedClient) {
+				r, err := client.TraceTransaction(ctx, testExtendedHash)
+				assert.NoError(t, err)
+				assert.Equal(t, testExtendedCallFrame(), r)
+
+				r, err = client.TraceTransaction(ctx, common.HexToHash("0x02"))
+				assert.EqualError(t, err, "transaction 0x0000000000000000000000000000000000000000000000000000000000000002 not found")
+				assert.Nil(t, r)
+			},
+		}, {
+			name: "TraceCall",
+			fn: func(t *testing.T, ctx context.Context, client ethhelpers.ExtendedClient) {
+				r, err := client.TraceCall(ctx, ethereum.CallMsg{To: &testExtendedAddress}, big.NewInt(10))
+				assert.NoError(t, err)
+				assert.Equal(t, testExtendedCallFrame(), r)
+			},
+		},
+	}
+
+	server := rpc.NewServer()
+	defer server.Stop()
+
+	services := map[string]interface{}{
+		"eth":    &testExtendedEthService{},
+		"web3":   &testExtendedWeb3Service{},
+		"txpool": &testExtendedTxPoolService{},
+		"debug":  &testExtendedDebugService{},
+	}
+
+	for name, service := range services {
+		if err := server.RegisterName(name, service); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rpcClient := rpc.DialInProc(server)
+	defer rpcClient.Close()
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			ctx := ethhelpers.ContextWithRPCClient(context.Background(), rpcClient)
+
+			client, ok := ethhelpers.ExtendedClientFromContext(ctx)
+			if !assert.True(t, ok) {
+				return
+			}
+
+			test.fn(t, ctx, client)
+		})
+	}
+}
+
+func TestNewExtendedClient(t *testing.T) {
+	client, err := ethhelpers.NewExtendedClient(nil)
+	assert.EqualError(t, err, "rpcClient must not be nil")
+	assert.Nil(t, client)
+
+	client, ok := ethhelpers.ExtendedClientFromContext(context.Background())
+	assert.False(t, ok)
+	assert.Nil(t, client)
+}
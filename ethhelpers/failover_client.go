@@ -0,0 +1,278 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultFailoverMaxFailures   = 3
+	DefaultFailoverProbeInterval = 10 * time.Second
+	DefaultFailoverProbeTimeout  = 5 * time.Second
+)
+
+type FailoverClientOptions struct {
+	// MaxFailures is the number of consecutive failed calls before an endpoint
+	// is taken out of rotation.
+	MaxFailures int
+
+	// ProbeInterval is the time between health probes of endpoints that are
+	// out of rotation.
+	ProbeInterval time.Duration
+
+	// ProbeTimeout is the timeout of each health probe.
+	ProbeTimeout time.Duration
+
+	// ProbeTick starts each round of health probes, or a ticker with
+	// ProbeInterval is used if nil.
+	ProbeTick <-chan time.Time
+
+	// Probe checks if an endpoint is healthy, or calls BlockNumber if nil.
+	Probe func(ctx context.Context, client Client) error
+
+	// RetryClass decides which errors cause the call to be passed to the next
+	// endpoint, or DefaultRetryClass if nil.
+	//
+	// Calls with RetryClassSend are only passed to the next endpoint if the
+	// error shows the node never received the call.
+	RetryClass func(method string) RetryClass
+}
+
+type failoverEndpoint struct {
+	client   Client
+	failures int
+	healthy  bool
+}
+
+type failoverClient struct {
+	mu        sync.Mutex
+	endpoints []*failoverEndpoint
+	current   int
+
+	opts      FailoverClientOptions
+	stop      func()
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewFailoverClient creates a client that passes calls to the current endpoint
+// and moves to the next endpoint on temporary errors.
+//
+// Endpoints are taken out of rotation after MaxFailures consecutive failures,
+// and put back when a background health probe succeeds. If no endpoints are in
+// rotation then all endpoints are tried.
+//
+// Close must be called to stop the health probe, which also closes all the
+// endpoint clients.
+func NewFailoverClient(clients []Client, opts FailoverClientOptions) (Client, error) {
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("clients must not be empty")
+	}
+	if opts.MaxFailures < 0 {
+		return nil, fmt.Errorf("opts.MaxFailures must not be negative")
+	}
+	if opts.ProbeInterval < 0 {
+		return nil, fmt.Errorf("opts.ProbeInterval must not be negative")
+	}
+	if opts.ProbeTimeout < 0 {
+		return nil, fmt.Errorf("opts.ProbeTimeout must not be negative")
+	}
+
+	if opts.MaxFailures == 0 {
+		opts.MaxFailures = DefaultFailoverMaxFailures
+	}
+	if opts.ProbeInterval == 0 {
+		opts.ProbeInterval = DefaultFailoverProbeInterval
+	}
+	if opts.ProbeTimeout == 0 {
+		opts.ProbeTimeout = DefaultFailoverProbeTimeout
+	}
+	if opts.Probe == nil {
+		opts.Probe = func(ctx context.Context, client Client) error {
+			_, err := client.BlockNumber(ctx)
+			return err
+		}
+	}
+	if opts.RetryClass == nil {
+		opts.RetryClass = DefaultRetryClass
+	}
+
+	endpoints := make([]*failoverEndpoint, len(clients))
+
+	for idx, client := range clients {
+		if client == nil {
+			return nil, fmt.Errorf("clients[%d] must not be nil", idx)
+		}
+
+		endpoints[idx] = &failoverEndpoint{
+			client:  client,
+			healthy: true,
+		}
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+
+	c := &failoverClient{
+		endpoints: endpoints,
+		opts:      opts,
+		stop:      stop,
+		done:      make(chan struct{}),
+	}
+
+	go c.probe(ctx)
+
+	return NewClientWithDefaultHandler(c.handle), nil
+}
+
+func (c *failoverClient) handle(ctx context.Context, caller ClientCaller) error {
+	class := c.opts.RetryClass(caller.Name())
+
+	if caller.Name() == "Close" {
+		c.closeOnce.Do(func() {
+			c.stop()
+			<-c.done
+
+			for _, ep := range c.endpoints {
+				_ = caller.Call(ctx, ep.client)
+			}
+		})
+
+		return nil
+	}
+
+	var lastErr error
+
+	for _, ep := range c.rotation() {
+		err := caller.Call(ctx, ep.client)
+
+		category := ClassifyError(err)
+
+		switch {
+		case category == ErrorCategoryNone:
+			c.succeeded(ep)
+			return nil
+		case class == RetryClassNone:
+			return err
+		case category == ErrorCategoryCircuitOpen:
+			// The call was not sent, so any class may use the next endpoint.
+		case class == RetryClassSend && category != ErrorCategoryUnavailable && category != ErrorCategoryRateLimited:
+			return err
+		case !category.IsTemporary():
+			c.succeeded(ep)
+			return err
+		}
+
+		c.failed(ep)
+		lastErr = err
+
+		if ctx.Err() != nil {
+			return lastErr
+		}
+	}
+
+	return lastErr
+}
+
+// rotation returns the endpoints in the order they should be tried.
+func (c *failoverClient) rotation() []*failoverEndpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	healthy := make([]*failoverEndpoint, 0, len(c.endpoints))
+	unhealthy := make([]*failoverEndpoint, 0, len(c.endpoints))
+
+	for idx := range c.endpoints {
+		ep := c.endpoints[(c.current+idx)%len(c.endpoints)]
+
+		if ep.healthy {
+			healthy = append(healthy, ep)
+		} else {
+			unhealthy = append(unhealthy, ep)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return unhealthy
+	}
+
+	return healthy
+}
+
+func (c *failoverClient) succeeded(ep *failoverEndpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ep.failures = 0
+}
+
+func (c *failoverClient) failed(ep *failoverEndpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ep.failures++
+
+	if ep.failures >= c.opts.MaxFailures {
+		ep.healthy = false
+	}
+
+	// Move to the next endpoint only if the failed endpoint is the current one,
+	// as concurrent calls might already have moved on.
+	if c.endpoints[c.current] == ep {
+		c.current = (c.current + 1) % len(c.endpoints)
+	}
+}
+
+func (c *failoverClient) probe(ctx context.Context) {
+	defer close(c.done)
+
+	tick := c.opts.ProbeTick
+
+	if tick == nil {
+		ticker := time.NewTicker(c.opts.ProbeInterval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-tick:
+		case <-ctx.Done():
+			return
+		}
+
+		for _, ep := range c.unhealthyEndpoints() {
+			err := func() error {
+				ctx, cancel := context.WithTimeout(ctx, c.opts.ProbeTimeout)
+				defer cancel()
+
+				return c.opts.Probe(ctx, ep.client)
+			}()
+			if err != nil {
+				continue
+			}
+
+			c.mu.Lock()
+			ep.failures = 0
+			ep.healthy = true
+			c.mu.Unlock()
+		}
+	}
+}
+
+func (c *failoverClient) unhealthyEndpoints() []*failoverEndpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var endpoints []*failoverEndpoint
+
+	for _, ep := range c.endpoints {
+		if !ep.healthy {
+			endpoints = append(endpoints, ep)
+		}
+	}
+
+	return endpoints
+}
@@ -0,0 +1,202 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFailoverClient(t *testing.T) {
+	type testArgs struct {
+		ctx    context.Context
+		client ethhelpers.Client
+		mocks  []*mock.Mock
+
+		// probe makes the next health probe return err, and returns after
+		// the endpoint health was updated.
+		probe func(err error)
+	}
+
+	tx := types.NewTransaction(1, common.HexToAddress("0x1234"), big.NewInt(1), 21000, big.NewInt(1), nil)
+	timeoutErr := &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}
+
+	tests := []struct {
+		name string
+		opts ethhelpers.FailoverClientOptions
+		fn   func(*testing.T, testArgs)
+	}{
+		{
+			name: "BlockNumber uses the first endpoint",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("BlockNumber", args.ctx).Return(uint64(123), nil).Twice()
+
+				for i := 0; i < 2; i++ {
+					blockNumber, err := args.client.BlockNumber(args.ctx)
+					assert.NoError(t, err)
+					assert.Equal(t, uint64(123), blockNumber)
+				}
+			},
+		}, {
+			name: "BlockNumber moves to the next endpoint on temporary error",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+				args.mocks[1].On("BlockNumber", args.ctx).Return(uint64(123), nil).Twice()
+
+				for i := 0; i < 2; i++ {
+					blockNumber, err := args.client.BlockNumber(args.ctx)
+					assert.NoError(t, err)
+					assert.Equal(t, uint64(123), blockNumber)
+				}
+			},
+		}, {
+			name: "BlockNumber returns last error if all endpoints fail",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+				args.mocks[1].On("BlockNumber", args.ctx).Return(uint64(0), timeoutErr).Once()
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.Same(t, timeoutErr, err)
+				assert.Equal(t, uint64(0), blockNumber)
+			},
+		}, {
+			name: "TransactionReceipt does not move to the next endpoint if not found",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("TransactionReceipt", args.ctx, tx.Hash()).Return(nil, ethereum.NotFound).Once()
+
+				receipt, err := args.client.TransactionReceipt(args.ctx, tx.Hash())
+				assert.ErrorIs(t, err, ethereum.NotFound)
+				assert.Nil(t, receipt)
+			},
+		}, {
+			name: "SendTransaction moves to the next endpoint if connection was refused",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("SendTransaction", args.ctx, tx).Return(syscall.ECONNREFUSED).Once()
+				args.mocks[1].On("SendTransaction", args.ctx, tx).Return(nil).Once()
+
+				err := args.client.SendTransaction(args.ctx, tx)
+				assert.NoError(t, err)
+			},
+		}, {
+			name: "SendTransaction moves to the next endpoint if the circuit is open",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("SendTransaction", args.ctx, tx).Return(&ethhelpers.CircuitOpenError{Method: "SendTransaction"}).Once()
+				args.mocks[1].On("SendTransaction", args.ctx, tx).Return(nil).Once()
+
+				err := args.client.SendTransaction(args.ctx, tx)
+				assert.NoError(t, err)
+			},
+		}, {
+			name: "SendTransaction does not move to the next endpoint after a timeout",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("SendTransaction", args.ctx, tx).Return(timeoutErr).Once()
+
+				err := args.client.SendTransaction(args.ctx, tx)
+				assert.Same(t, timeoutErr, err)
+			},
+		}, {
+			name: "BlockNumber uses endpoint put back in rotation by health probe",
+			opts: ethhelpers.FailoverClientOptions{
+				MaxFailures: 1,
+			},
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+				args.mocks[1].On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(123), blockNumber)
+
+				args.probe(nil)
+
+				args.mocks[1].On("BlockNumber", args.ctx).Return(uint64(0), syscall.ECONNRESET).Once()
+				args.mocks[0].On("BlockNumber", args.ctx).Return(uint64(125), nil).Once()
+
+				blockNumber, err = args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(125), blockNumber)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			clients := []ethtesting.ClientWithMock{
+				ethtesting.NewClientWithMock(),
+				ethtesting.NewClientWithMock(),
+			}
+
+			for _, c := range clients {
+				c.Test(t)
+				c.Mock().On("Close").Return(nil).Once()
+			}
+
+			tick := make(chan time.Time)
+			probeErr := make(chan error)
+
+			opts := test.opts
+			opts.ProbeTick = tick
+			opts.Probe = func(ctx context.Context, client ethhelpers.Client) error {
+				select {
+				case err := <-probeErr:
+					return err
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			client, err := ethhelpers.NewFailoverClient([]ethhelpers.Client{clients[0], clients[1]}, opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			test.fn(t, testArgs{
+				ctx,
+				client,
+				[]*mock.Mock{clients[0].Mock(), clients[1].Mock()},
+				func(err error) {
+					tick <- time.Now()
+					probeErr <- err
+
+					// The next tick is received after the round of probes.
+					tick <- time.Now()
+				},
+			})
+
+			// Endpoints are closed only once.
+			client.Close()
+			client.Close()
+
+			for _, c := range clients {
+				c.Mock().AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestNewFailoverClient_InvalidArguments(t *testing.T) {
+	_, err := ethhelpers.NewFailoverClient(nil, ethhelpers.FailoverClientOptions{})
+	assert.Error(t, err)
+
+	_, err = ethhelpers.NewFailoverClient([]ethhelpers.Client{nil}, ethhelpers.FailoverClientOptions{})
+	assert.Error(t, err)
+
+	_, err = ethhelpers.NewFailoverClient([]ethhelpers.Client{ethtesting.NewClientWithMock()}, ethhelpers.FailoverClientOptions{MaxFailures: -1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,267 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// filterUninstallTimeout is the timeout of the request uninstalling the
+// filter when a subscription ends, as the subscription context is done.
+const filterUninstallTimeout = 5 * time.Second
+
+type FilterChangesSubscriberOptions struct {
+	RPCClient *rpc.Client
+
+	// CreateContext returns a context that is used for the subscription, or
+	// context.Background() if nil.
+	CreateContext func() (context.Context, context.CancelFunc)
+
+	// CreateTicker is a function that creates a block number ticker, see
+	// HTTPSubscriberOptions.CreateTicker.
+	CreateTicker func(ctx context.Context, fromBlock uint64) (BlockNumberTicker, error)
+
+	// FilterQuery is the query of the filter, with FromBlock and ToBlock
+	// ignored as only the logs of new blocks are sent.
+	FilterQuery ethereum.FilterQuery
+	Logs        chan<- types.Log
+
+	// Metrics records the health of the subscription, if not nil.
+	Metrics *SubscriptionMetrics
+
+	// Backfill configures the requests made to send the logs of the blocks
+	// missed when the filter expired, with the defaults used if nil.
+	Backfill *BackfillOptions
+}
+
+// SubscribeFilterLogsWithFilterChanges installs a filter with eth_newFilter
+// and sends the logs returned by eth_getFilterChanges each time a block number
+// is ticked.
+//
+// Logs removed by a reorg are sent with Removed set if the node reports them.
+//
+// If the node has removed the filter, e.g. because it was not polled within
+// the node's filter timeout, the filter is installed again and the logs of
+// the blocks in between are requested with eth_getLogs.
+//
+// The context and Unsubscribe semantics are the same as for
+// SubscribeFilterLogsWithHTTP, and the filter is uninstalled when the
+// subscription ends.
+func SubscribeFilterLogsWithFilterChanges(callerCtx context.Context, opts *FilterChangesSubscriberOptions) (ethereum.Subscription, error) {
+	if opts.RPCClient == nil {
+		return nil, fmt.Errorf("opts.RPCClient must be set")
+	}
+	if opts.CreateTicker == nil {
+		return nil, fmt.Errorf("opts.CreateTicker must be set")
+	}
+	if opts.Logs == nil {
+		return nil, fmt.Errorf("opts.Logs must be set")
+	}
+	if opts.FilterQuery.BlockHash != nil {
+		return nil, fmt.Errorf("opts.FilterQuery.BlockHash must not be set")
+	}
+
+	backfillOpts := BackfillOptions{}
+	if opts.Backfill != nil {
+		backfillOpts = *opts.Backfill
+	}
+
+	backfillOpts, err := backfillOpts.withDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid backfill options: %w", err)
+	}
+
+	p := &logFilterPoller{
+		filter:     rpcFilter{rpcClient: opts.RPCClient},
+		client:     ethclient.NewClient(opts.RPCClient),
+		q:          opts.FilterQuery,
+		backfiller: newLogBackfiller(backfillOpts),
+	}
+
+	if err := p.install(callerCtx); err != nil {
+		return nil, err
+	}
+
+	return httpSubscriber{
+		client:        p.client,
+		createContext: opts.CreateContext,
+		createTicker:  opts.CreateTicker,
+		metrics:       opts.Metrics,
+
+		deliver: func(ctx context.Context, fromBlock, toBlock uint64, bn BlockNumber) error {
+			return p.poll(ctx, toBlock, func(logs []types.Log) error {
+				for _, log := range logs {
+					select {
+					case opts.Logs <- log:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+
+				opts.Metrics.logsDelivered(len(logs), bn.Timestamp)
+
+				return nil
+			})
+		},
+		cleanup: p.filter.uninstall,
+	}.subscribe(callerCtx)
+}
+
+// rpcFilter is a filter installed on the node, polled with
+// eth_getFilterChanges.
+type rpcFilter struct {
+	rpcClient *rpc.Client
+	id        string
+}
+
+// install installs a filter with method, replacing the id of any previous
+// filter without uninstalling it.
+func (f *rpcFilter) install(ctx context.Context, method string, args ...interface{}) error {
+	var id string
+
+	if err := f.rpcClient.CallContext(ctx, &id, method, args...); err != nil {
+		return fmt.Errorf("failed to install filter: %w", err)
+	}
+
+	f.id = id
+
+	return nil
+}
+
+func (f *rpcFilter) changes(ctx context.Context, result interface{}) error {
+	return f.rpcClient.CallContext(ctx, result, "eth_getFilterChanges", f.id)
+}
+
+// uninstall removes the filter from the node, ignoring any error as the node
+// removes expired filters.
+func (f *rpcFilter) uninstall() {
+	if f.id == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), filterUninstallTimeout)
+	defer cancel()
+
+	var ok bool
+	_ = f.rpcClient.CallContext(ctx, &ok, "eth_uninstallFilter", f.id)
+
+	f.id = ""
+}
+
+// logFilterPoller polls a log filter installed on the node, installing it
+// again if it has expired.
+type logFilterPoller struct {
+	filter     rpcFilter
+	client     HTTPSubscriberClient
+	q          ethereum.FilterQuery
+	backfiller *logBackfiller
+
+	// lastBlock is the last block whose logs have all been sent, or are
+	// returned by the filter.
+	lastBlock uint64
+
+	// skipToBlock is the last block backfilled after the filter was
+	// installed again, whose logs are skipped in the next filter changes as
+	// they may already have been sent.
+	skipToBlock *uint64
+}
+
+// install installs the filter and sets lastBlock to the current block number
+// as seen after the filter was installed.
+func (p *logFilterPoller) install(ctx context.Context) error {
+	// The filter starts at the latest block when fromBlock is not set.
+	arg := map[string]interface{}{
+		"address": p.q.Addresses,
+		"topics":  p.q.Topics,
+	}
+
+	if err := p.filter.install(ctx, "eth_newFilter", arg); err != nil {
+		return err
+	}
+
+	blockNumber, err := p.client.BlockNumber(ctx)
+	if err != nil {
+		p.filter.uninstall()
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+
+	p.lastBlock = blockNumber
+
+	return nil
+}
+
+// poll sends the filter changes after currentBlock was ticked, or the logs
+// of the blocks missed if the filter had expired and was installed again.
+func (p *logFilterPoller) poll(ctx context.Context, currentBlock uint64, send func([]types.Log) error) error {
+	var logs []types.Log
+
+	if err := p.filter.changes(ctx, &logs); err != nil {
+		if ClassifyError(err) != ErrorCategoryFilterNotFound {
+			return fmt.Errorf("failed to get filter changes: %w", err)
+		}
+
+		return p.reinstall(ctx, send)
+	}
+
+	if p.skipToBlock != nil {
+		skipToBlock := *p.skipToBlock
+		p.skipToBlock = nil
+
+		var filtered []types.Log
+
+		for _, log := range logs {
+			if log.Removed || log.BlockNumber > skipToBlock {
+				filtered = append(filtered, log)
+			}
+		}
+
+		logs = filtered
+	}
+
+	if err := send(logs); err != nil {
+		return err
+	}
+
+	if currentBlock > p.lastBlock {
+		p.lastBlock = currentBlock
+	}
+
+	for _, log := range logs {
+		if !log.Removed && log.BlockNumber > p.lastBlock {
+			p.lastBlock = log.BlockNumber
+		}
+	}
+
+	return nil
+}
+
+// reinstall installs the filter again and sends the logs of the blocks after
+// lastBlock up to the current block.
+func (p *logFilterPoller) reinstall(ctx context.Context, send func([]types.Log) error) error {
+	fromBlock := p.lastBlock + 1
+
+	if err := p.install(ctx); err != nil {
+		return fmt.Errorf("failed to reinstall expired filter: %w", err)
+	}
+
+	toBlock := p.lastBlock
+	p.skipToBlock = &toBlock
+
+	if fromBlock > toBlock {
+		return nil
+	}
+
+	return p.backfiller.backfill(ctx, fromBlock, toBlock,
+		func(ctx context.Context, fromBlock, toBlock uint64) ([]types.Log, error) {
+			return filterLogsInRange(ctx, p.client, p.q, fromBlock, toBlock)
+		},
+		func(logs []types.Log, toBlock uint64) error {
+			return send(logs)
+		},
+	)
+}
@@ -0,0 +1,383 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+)
+
+// testFilterEthService is a chain with one log per block that supports
+// filters, where each filter returns the logs of the blocks after the block
+// it last returned.
+type testFilterEthService struct {
+	mu      sync.Mutex
+	current uint64
+	nextID  int
+	filters map[string]uint64
+
+	// installLag is the number of blocks before the current block that new
+	// filters start from, to simulate blocks added while installing.
+	installLag uint64
+}
+
+func newTestFilterEthService(current uint64) *testFilterEthService {
+	return &testFilterEthService{
+		current: current,
+		filters: make(map[string]uint64),
+	}
+}
+
+func testFilterLog(n uint64) types.Log {
+	return types.Log{
+		BlockNumber: n,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(n)),
+		Topics:      []common.Hash{},
+		Data:        []byte{},
+	}
+}
+
+func (s *testFilterEthService) setCurrent(n uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.current = n
+}
+
+func (s *testFilterEthService) expireFilters() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.filters = make(map[string]uint64)
+}
+
+func (s *testFilterEthService) filterCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.filters)
+}
+
+func (s *testFilterEthService) BlockNumber() hexutil.Uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return hexutil.Uint64(s.current)
+}
+
+func (s *testFilterEthService) NewFilter(crit map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	id := hexutil.EncodeUint64(uint64(s.nextID))
+	s.filters[id] = s.current - s.installLag
+
+	return id
+}
+
+func (s *testFilterEthService) GetFilterChanges(id string) ([]types.Log, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last, ok := s.filters[id]
+	if !ok {
+		return nil, fmt.Errorf("filter not found")
+	}
+
+	logs := []types.Log{}
+	for n := last + 1; n <= s.current; n++ {
+		logs = append(logs, testFilterLog(n))
+	}
+
+	s.filters[id] = s.current
+
+	return logs, nil
+}
+
+func (s *testFilterEthService) UninstallFilter(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.filters[id]
+	delete(s.filters, id)
+
+	return ok
+}
+
+func (s *testFilterEthService) GetLogs(crit struct {
+	FromBlock hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   hexutil.Uint64 `json:"toBlock"`
+}) []types.Log {
+	logs := []types.Log{}
+	for n := uint64(crit.FromBlock); n <= uint64(crit.ToBlock); n++ {
+		logs = append(logs, testFilterLog(n))
+	}
+
+	return logs
+}
+
+func TestSubscribeFilterLogsWithFilterChanges(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, service *testFilterEthService, tick func(uint64), expect func(...uint64))
+	}{
+		{
+			name: "filter changes are sent on each tick",
+			fn: func(t *testing.T, service *testFilterEthService, tick func(uint64), expect func(...uint64)) {
+				tick(11)
+				expect(11)
+
+				tick(13)
+				expect(12, 13)
+			},
+		}, {
+			name: "expired filter is reinstalled and the missed blocks backfilled",
+			fn: func(t *testing.T, service *testFilterEthService, tick func(uint64), expect func(...uint64)) {
+				tick(11)
+				expect(11)
+
+				service.expireFilters()
+				service.mu.Lock()
+				service.installLag = 2
+				service.mu.Unlock()
+
+				tick(14)
+				expect(12, 13, 14)
+
+				tick(15)
+				expect(15)
+
+				assert.Equal(t, 1, service.filterCount())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			service := newTestFilterEthService(10)
+
+			server := rpc.NewServer()
+			defer server.Stop()
+
+			if err := server.RegisterName("eth", service); err != nil {
+				t.Fatal(err)
+			}
+
+			rpcClient := rpc.DialInProc(server)
+			defer rpcClient.Close()
+
+			ticker := &testBlockNumberTicker{
+				wait: make(chan ethhelpers.BlockNumber),
+				err:  make(chan error),
+			}
+
+			logs := make(chan types.Log, 16)
+
+			sub, err := ethhelpers.SubscribeFilterLogsWithFilterChanges(ctx, &ethhelpers.FilterChangesSubscriberOptions{
+				RPCClient: rpcClient,
+				CreateTicker: func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+					return ticker, nil
+				},
+				Logs:     logs,
+				Backfill: &ethhelpers.BackfillOptions{ChunkSize: 2},
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, 1, service.filterCount())
+
+			tick := func(n uint64) {
+				service.setCurrent(n)
+
+				select {
+				case ticker.wait <- ethhelpers.BlockNumber{BlockNumber: n}:
+				case <-ctx.Done():
+					t.Fatal("timed out")
+				}
+			}
+			expect := func(expected ...uint64) {
+				for _, n := range expected {
+					select {
+					case log := <-logs:
+						assert.Equal(t, testFilterLog(n), log)
+					case err := <-sub.Err():
+						t.Fatalf("unexpected error: %v", err)
+					case <-ctx.Done():
+						t.Fatal("timed out")
+					}
+				}
+			}
+
+			tick(10)
+			test.fn(t, service, tick, expect)
+
+			sub.Unsubscribe()
+
+			assert.Empty(t, logs)
+			assert.Equal(t, 0, service.filterCount())
+		})
+	}
+}
+
+func TestSubscribeFilterLogsWithFilterChanges_InvalidOptions(t *testing.T) {
+	createTicker := func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+		return nil, fmt.Errorf("not called")
+	}
+
+	tests := []struct {
+		name string
+		opts ethhelpers.FilterChangesSubscriberOptions
+		err  string
+	}{
+		{
+			name: "missing rpc client",
+			opts: ethhelpers.FilterChangesSubscriberOptions{CreateTicker: createTicker, Logs: make(chan types.Log)},
+			err:  "opts.RPCClient must be set",
+		}, {
+			name: "block hash",
+			opts: ethhelpers.FilterChangesSubscriberOptions{
+				RPCClient:    &rpc.Client{},
+				CreateTicker: createTicker,
+				FilterQuery:  ethereum.FilterQuery{BlockHash: &common.Hash{}},
+				Logs:         make(chan types.Log),
+			},
+			err: "opts.FilterQuery.BlockHash must not be set",
+		}, {
+			name: "invalid backfill options",
+			opts: ethhelpers.FilterChangesSubscriberOptions{
+				RPCClient:    &rpc.Client{},
+				CreateTicker: createTicker,
+				Logs:         make(chan types.Log),
+				Backfill:     &ethhelpers.BackfillOptions{MinChunkSize: 10, MaxChunkSize: 5},
+			},
+			err: "invalid backfill options: opts.MaxChunkSize must not be less than opts.MinChunkSize",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			sub, err := ethhelpers.SubscribeFilterLogsWithFilterChanges(context.Background(), &test.opts)
+			assert.EqualError(t, err, test.err)
+			assert.Nil(t, sub)
+		})
+	}
+}
+
+func TestClientWithHTTPSubscriptions_FilterChangesStrategy(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	service := newTestFilterEthService(10)
+
+	server := rpc.NewServer()
+	defer server.Stop()
+
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+
+	rpcClient := rpc.DialInProc(server)
+	defer rpcClient.Close()
+
+	ticker := &testBlockNumberTicker{
+		wait: make(chan ethhelpers.BlockNumber, 2),
+		err:  make(chan error),
+	}
+	ticker.wait <- ethhelpers.BlockNumber{BlockNumber: 10}
+
+	client, err := ethhelpers.NewClientWithHTTPSubscriptionsOptions(ethtesting.NewClientWithMock(), ethhelpers.HTTPSubscriptionsOptions{
+		CreateTicker: func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+			return ticker, nil
+		},
+		LogStrategy: ethhelpers.FilterChangesStrategy,
+		RPCClient:   rpcClient,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	logs := make(chan types.Log)
+
+	sub, err := client.SubscribeFilterLogs(ctx, ethereum.FilterQuery{}, logs)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer sub.Unsubscribe()
+
+	service.setCurrent(11)
+	ticker.wait <- ethhelpers.BlockNumber{BlockNumber: 11}
+
+	select {
+	case log := <-logs:
+		assert.Equal(t, testFilterLog(11), log)
+	case err := <-sub.Err():
+		t.Fatalf("unexpected error: %v", err)
+	case <-ctx.Done():
+		t.Fatal("timed out")
+	}
+}
+
+func TestNewClientWithHTTPSubscriptionsOptions_InvalidOptions(t *testing.T) {
+	createTicker := func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+		return nil, fmt.Errorf("not called")
+	}
+
+	tests := []struct {
+		name   string
+		client ethhelpers.Client
+		opts   ethhelpers.HTTPSubscriptionsOptions
+		err    string
+	}{
+		{
+			name: "nil client",
+			opts: ethhelpers.HTTPSubscriptionsOptions{CreateTicker: createTicker},
+			err:  "client must not be nil",
+		}, {
+			name:   "missing ticker",
+			client: ethtesting.NewClientWithMock(),
+			err:    "opts.CreateTicker must be set",
+		}, {
+			name:   "filter changes without rpc client",
+			client: ethtesting.NewClientWithMock(),
+			opts:   ethhelpers.HTTPSubscriptionsOptions{CreateTicker: createTicker, LogStrategy: ethhelpers.FilterChangesStrategy},
+			err:    "opts.RPCClient must be set if opts.LogStrategy is FilterChangesStrategy",
+		}, {
+			name:   "invalid strategy",
+			client: ethtesting.NewClientWithMock(),
+			opts:   ethhelpers.HTTPSubscriptionsOptions{CreateTicker: createTicker, LogStrategy: 10},
+			err:    "opts.LogStrategy is not a valid strategy",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			client, err := ethhelpers.NewClientWithHTTPSubscriptionsOptions(test.client, test.opts)
+			assert.EqualError(t, err, test.err)
+			assert.Nil(t, client)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -50,6 +51,80 @@ type HTTPSubscriberOptions struct {
 
 	FilterQuery ethereum.FilterQuery
 	Logs        chan<- types.Log
+
+	// Metrics records the health of the subscription, if not nil.
+	Metrics *SubscriptionMetrics
+
+	// ReorgTrackingDepth is the number of recent blocks whose hashes and logs
+	// are tracked to detect reorgs, or zero to disable reorg detection.
+	//
+	// When the parent hash of a new block does not match the tracked block,
+	// the logs of the removed blocks are sent again with Removed set to true
+	// before the logs of the new canonical blocks. A reorg deeper than the
+	// tracking depth is returned as a subscription error.
+	//
+	// Client must implement HeaderByNumberReader if set.
+	ReorgTrackingDepth uint64
+
+	// Backfill enables sending the logs of past blocks, from
+	// FilterQuery.FromBlock up to the first block number ticked, before
+	// continuing with the logs of new blocks without a gap or duplicate.
+	//
+	// If nil, the logs of blocks before the first block number ticked are
+	// not sent. If Confirmations is set, the past blocks are backfilled as
+	// they are confirmed.
+	Backfill *BackfillOptions
+
+	// Confirmations holds the logs of each block until it is confirmed, if
+	// not nil. Logs of blocks removed by a reorg before they are confirmed
+	// are never sent.
+	Confirmations *ConfirmationOptions
+}
+
+type HTTPHeadSubscriberClient interface {
+	BlockNumberReader
+	HeaderByNumberReader
+}
+
+type HTTPHeadSubscriberOptions struct {
+	Client HTTPHeadSubscriberClient
+
+	// CreateContext returns a context that is used for the subscription, or
+	// context.Background() if nil.
+	CreateContext func() (context.Context, context.CancelFunc)
+
+	// CreateTicker is a function that creates a block number ticker, see
+	// HTTPSubscriberOptions.CreateTicker.
+	CreateTicker func(ctx context.Context, fromBlock uint64) (BlockNumberTicker, error)
+
+	Headers chan<- *types.Header
+
+	// Metrics records the health of the subscription, if not nil.
+	Metrics *SubscriptionMetrics
+}
+
+// httpSubscriber holds what is shared by the HTTP subscribers, with deliver
+// called for each range of block numbers, in order and without gaps.
+type httpSubscriber struct {
+	client        BlockNumberReader
+	createContext func() (context.Context, context.CancelFunc)
+	createTicker  func(ctx context.Context, fromBlock uint64) (BlockNumberTicker, error)
+	fromBlock     *uint64
+	metrics       *SubscriptionMetrics
+
+	deliver func(ctx context.Context, fromBlock, toBlock uint64, bn BlockNumber) error
+
+	// backfill is called with the blocks from fromBlock up to the first
+	// block number ticked, if not nil.
+	backfill func(ctx context.Context, fromBlock, toBlock uint64) error
+
+	// confirmed returns the last block that can be delivered when the
+	// current block is ticked, or false if none, if not nil.
+	confirmed func(ctx context.Context, currentBlock uint64) (uint64, bool, error)
+
+	// cleanup is called when the subscription ends or fails to be created,
+	// if not nil.
+	cleanup func()
 }
 
 // The context argument cancels the RPC request that sets up the subscription
@@ -63,6 +138,65 @@ type HTTPSubscriberOptions struct {
 //
 // The current block number is requested before the subscription is returned.
 func SubscribeFilterLogsWithHTTP(callerCtx context.Context, opts *HTTPSubscriberOptions) (ethereum.Subscription, error) {
+	return subscribeFilterLogsWithHTTP(callerCtx, opts, nil)
+}
+
+// SubscribeFilterLogsWithCheckpoint subscribes to logs like
+// SubscribeFilterLogsWithHTTP, saving the last block whose logs have all been
+// sent and acknowledged in the checkpoint store.
+//
+// If a checkpoint has been saved for the key, the subscription resumes from
+// the block after it instead of opts.FilterQuery.FromBlock, so that logs are
+// received at least once and without gaps across restarts. The logs of past
+// blocks are backfilled with the default options if opts.Backfill is nil.
+//
+// The checkpoint is held back by checkpoint.Confirmations blocks unless
+// opts.Confirmations is set, so that the new logs of blocks replaced by a
+// reorg during a restart are sent. Removed logs are not sent for such reorgs.
+func SubscribeFilterLogsWithCheckpoint(callerCtx context.Context, opts *HTTPSubscriberOptions, checkpoint CheckpointOptions) (CheckpointedSubscription, error) {
+	if err := checkpoint.validate(); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint options: %w", err)
+	}
+
+	blockNumber, ok, err := checkpoint.Store.LoadCheckpoint(callerCtx, checkpoint.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	resumeOpts := *opts
+
+	if ok {
+		resumeOpts.FilterQuery.FromBlock = new(big.Int).Add(new(big.Int).SetUint64(blockNumber), big.NewInt(1))
+	}
+	if resumeOpts.Backfill == nil {
+		resumeOpts.Backfill = &BackfillOptions{}
+	}
+
+	depth := checkpoint.Confirmations
+
+	switch {
+	case opts.Confirmations != nil:
+		depth = 0
+	case depth == 0:
+		depth = DefaultCheckpointConfirmations
+	}
+
+	checkpointer := newLogCheckpointer(checkpoint, depth)
+
+	sub, err := subscribeFilterLogsWithHTTP(callerCtx, &resumeOpts, checkpointer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkpointedSubscription{
+		Subscription: sub,
+		checkpointer: checkpointer,
+	}, nil
+}
+
+// subscribeFilterLogsWithHTTP subscribes to logs, reporting the logs sent and
+// the blocks delivered to checkpointer if not nil.
+func subscribeFilterLogsWithHTTP(callerCtx context.Context, opts *HTTPSubscriberOptions, checkpointer *logCheckpointer) (ethereum.Subscription, error) {
 	if opts.Client == nil {
 		return nil, fmt.Errorf("opts.Client must be set")
 	}
@@ -73,12 +207,168 @@ func SubscribeFilterLogsWithHTTP(callerCtx context.Context, opts *HTTPSubscriber
 		return nil, fmt.Errorf("opts.Logs must be set")
 	}
 
+	var fromBlock *uint64
+
+	if opts.FilterQuery.FromBlock != nil {
+		if !opts.FilterQuery.FromBlock.IsUint64() {
+			return nil, fmt.Errorf("opts.FilterQuery.FromBlock is too large")
+		}
+
+		n := opts.FilterQuery.FromBlock.Uint64()
+		fromBlock = &n
+	}
+
+	var tracker *logReorgTracker
+
+	if opts.ReorgTrackingDepth != 0 {
+		headerReader, ok := opts.Client.(HeaderByNumberReader)
+		if !ok {
+			return nil, fmt.Errorf("opts.Client must implement HeaderByNumberReader when opts.ReorgTrackingDepth is set")
+		}
+
+		tracker = newLogReorgTracker(headerReader, opts.ReorgTrackingDepth, opts.Metrics)
+	}
+
+	filterLogs := func(ctx context.Context, fromBlock, toBlock uint64) ([]types.Log, error) {
+		if tracker != nil {
+			return tracker.filterLogs(ctx, opts.Client, opts.FilterQuery, fromBlock, toBlock)
+		}
+
+		return filterLogsInRange(ctx, opts.Client, opts.FilterQuery, fromBlock, toBlock)
+	}
+
+	sendLogs := func(ctx context.Context, logs []types.Log, fromBlock, toBlock uint64, tick time.Time) error {
+		for _, log := range logs {
+			if checkpointer != nil {
+				checkpointer.logSent(log)
+			}
+
+			select {
+			case opts.Logs <- log:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		opts.Metrics.logsDelivered(len(logs), tick)
+
+		if checkpointer != nil {
+			return checkpointer.delivered(ctx, fromBlock, toBlock)
+		}
+
+		return nil
+	}
+
+	var confirmed func(ctx context.Context, currentBlock uint64) (uint64, bool, error)
+
+	if opts.Confirmations != nil {
+		if err := opts.Confirmations.validate(); err != nil {
+			return nil, fmt.Errorf("invalid confirmation options: %w", err)
+		}
+
+		confirmed = opts.Confirmations.confirmedBlock
+	}
+
+	var backfill func(ctx context.Context, fromBlock, toBlock uint64) error
+
+	if opts.Backfill != nil {
+		backfillOpts, err := opts.Backfill.withDefaults()
+		if err != nil {
+			return nil, fmt.Errorf("invalid backfill options: %w", err)
+		}
+
+		backfiller := newLogBackfiller(backfillOpts)
+
+		backfill = func(ctx context.Context, fromBlock, toBlock uint64) error {
+			return backfiller.backfill(ctx, fromBlock, toBlock,
+				func(ctx context.Context, chunkFrom, chunkTo uint64) ([]types.Log, error) {
+					// Only blocks within the tracking depth of the first
+					// block ticked need to be tracked.
+					if tracker != nil && chunkTo+opts.ReorgTrackingDepth > toBlock {
+						return tracker.filterLogs(ctx, opts.Client, opts.FilterQuery, chunkFrom, chunkTo)
+					}
+
+					return filterLogsInRange(ctx, opts.Client, opts.FilterQuery, chunkFrom, chunkTo)
+				},
+				func(logs []types.Log, chunkTo uint64) error {
+					return sendLogs(ctx, logs, fromBlock, chunkTo, time.Time{})
+				},
+			)
+		}
+	}
+
+	return httpSubscriber{
+		client:        opts.Client,
+		createContext: opts.CreateContext,
+		createTicker:  opts.CreateTicker,
+		fromBlock:     fromBlock,
+		metrics:       opts.Metrics,
+
+		deliver: func(ctx context.Context, fromBlock, toBlock uint64, bn BlockNumber) error {
+			logs, err := filterLogs(ctx, fromBlock, toBlock)
+			if err != nil {
+				return err
+			}
+
+			return sendLogs(ctx, logs, fromBlock, toBlock, bn.Timestamp)
+		},
+		backfill:  backfill,
+		confirmed: confirmed,
+	}.subscribe(callerCtx)
+}
+
+// SubscribeNewHeadWithHTTP polls for new blocks with a block number ticker and
+// sends the header of every block in the ticked range, in order and without
+// gaps.
+//
+// The context and Unsubscribe semantics are the same as for
+// SubscribeFilterLogsWithHTTP, and the first header sent is that of the first
+// block number ticked.
+func SubscribeNewHeadWithHTTP(callerCtx context.Context, opts *HTTPHeadSubscriberOptions) (ethereum.Subscription, error) {
+	if opts.Client == nil {
+		return nil, fmt.Errorf("opts.Client must be set")
+	}
+	if opts.CreateTicker == nil {
+		return nil, fmt.Errorf("opts.CreateTicker must be set")
+	}
+	if opts.Headers == nil {
+		return nil, fmt.Errorf("opts.Headers must be set")
+	}
+
+	return httpSubscriber{
+		client:        opts.Client,
+		createContext: opts.CreateContext,
+		createTicker:  opts.CreateTicker,
+		metrics:       opts.Metrics,
+
+		deliver: func(ctx context.Context, fromBlock, toBlock uint64, bn BlockNumber) error {
+			for n := fromBlock; n <= toBlock; n++ {
+				header, err := opts.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+				if err != nil {
+					return fmt.Errorf("failed to get header for block %d: %w", n, err)
+				}
+
+				select {
+				case opts.Headers <- header:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			opts.Metrics.tickDelivered(bn.Timestamp)
+
+			return nil
+		},
+	}.subscribe(callerCtx)
+}
+
+func (h httpSubscriber) subscribe(callerCtx context.Context) (ethereum.Subscription, error) {
 	subscriberCtx, cancel := func() (context.Context, context.CancelFunc) {
-		if opts.CreateContext == nil {
+		if h.createContext == nil {
 			return context.WithCancel(context.Background())
 		}
 
-		return opts.CreateContext()
+		return h.createContext()
 	}()
 
 	ticker, err := func(ctx context.Context, done <-chan struct{}) (BlockNumberTicker, error) {
@@ -93,29 +383,25 @@ func SubscribeFilterLogsWithHTTP(callerCtx context.Context, opts *HTTPSubscriber
 			}
 		}()
 
-		currentBlock, err := opts.Client.BlockNumber(ctx)
+		currentBlock, err := h.client.BlockNumber(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get current block number: %w", err)
 		}
 
-		if opts.FilterQuery.FromBlock == nil {
-			return opts.CreateTicker(ctx, currentBlock)
-		}
-		if !opts.FilterQuery.FromBlock.IsUint64() {
-			return nil, fmt.Errorf("opts.FilterQuery.FromBlock is too large")
+		if h.fromBlock != nil && currentBlock < *h.fromBlock {
+			return h.createTicker(ctx, *h.fromBlock)
 		}
 
-		queryFromBlock := opts.FilterQuery.FromBlock.Uint64()
-
-		if currentBlock < queryFromBlock {
-			return opts.CreateTicker(ctx, queryFromBlock)
-		} else {
-			return opts.CreateTicker(ctx, currentBlock)
-		}
+		return h.createTicker(ctx, currentBlock)
 
 	}(subscriberCtx, callerCtx.Done())
 	if err != nil {
 		cancel()
+
+		if h.cleanup != nil {
+			h.cleanup()
+		}
+
 		return nil, fmt.Errorf("failed to create block ticker: %w", err)
 	}
 
@@ -128,70 +414,135 @@ func SubscribeFilterLogsWithHTTP(callerCtx context.Context, opts *HTTPSubscriber
 	go func(ctx context.Context) {
 		defer close(s.done)
 
-		waitFn := func() (uint64, bool) {
+		if h.cleanup != nil {
+			defer h.cleanup()
+		}
+
+		waitFn := func() (BlockNumber, bool) {
 			select {
 			case bn, ok := <-ticker.Wait():
 				if !ok {
 					s.err <- fmt.Errorf("block ticker wait channel closed")
-					return 0, false
+					return BlockNumber{}, false
 				}
 
-				return bn.BlockNumber, true
+				return bn, true
 
 			case err, ok := <-ticker.Err():
 				if !ok {
 					s.err <- fmt.Errorf("block number ticker closed the error channel")
-					return 0, false
+					return BlockNumber{}, false
 				}
 				if err == nil {
 					s.err <- fmt.Errorf("block number ticker returned a nil error")
-					return 0, false
+					return BlockNumber{}, false
 				}
 
 				s.err <- err
-				return 0, false
+				return BlockNumber{}, false
 
 			case <-ctx.Done():
 				s.err <- ctx.Err()
-				return 0, false
+				return BlockNumber{}, false
 			}
 		}
 
-		fromBlock, ok := waitFn()
+		bn, ok := waitFn()
 		if !ok {
 			return
 		}
 
+		fromBlock := bn.BlockNumber
+
+		// The blocks before the first block ticked up to backfillTo are sent
+		// with backfill, including those left for later ticks as they are not
+		// yet confirmed.
+		backfilling := h.backfill != nil && h.fromBlock != nil && *h.fromBlock < fromBlock
+		backfillTo := fromBlock - 1
+
+		if backfilling {
+			fromBlock = *h.fromBlock
+
+			// Blocks that are not yet confirmed are left for the ticks.
+			confirmed, ok := backfillTo, true
+
+			if h.confirmed != nil {
+				var err error
+
+				if confirmed, ok, err = h.confirmed(ctx, bn.BlockNumber); err != nil {
+					s.err <- err
+					return
+				}
+				if confirmed > backfillTo {
+					confirmed = backfillTo
+				}
+			}
+
+			if ok && confirmed >= fromBlock {
+				if err := h.backfill(ctx, fromBlock, confirmed); err != nil {
+					s.err <- err
+					return
+				}
+
+				fromBlock = confirmed + 1
+			}
+		}
+
 		for {
-			currentBlock, ok := waitFn()
+			bn, ok := waitFn()
 			if !ok {
 				return
 			}
+
+			currentBlock := bn.BlockNumber
+
 			if currentBlock < fromBlock {
 				s.err <- fmt.Errorf("block number ticker returned a block number less than the from block")
 				return
 			}
 
-			q := opts.FilterQuery
-			q.FromBlock = new(big.Int).SetUint64(fromBlock)
-			q.ToBlock = new(big.Int).SetUint64(currentBlock)
+			h.metrics.blockReceived()
 
-			logs, err := opts.Client.FilterLogs(ctx, q)
-			if err != nil {
-				s.err <- err
-				return
+			toBlock := currentBlock
+
+			if h.confirmed != nil {
+				confirmed, ok, err := h.confirmed(ctx, currentBlock)
+				if err != nil {
+					s.err <- err
+					return
+				}
+				if !ok || confirmed < fromBlock {
+					continue
+				}
+				if confirmed < toBlock {
+					toBlock = confirmed
+				}
 			}
 
-			for _, log := range logs {
-				select {
-				case opts.Logs <- log:
-				case <-ctx.Done():
+			if backfilling && fromBlock <= backfillTo {
+				end := toBlock
+				if end > backfillTo {
+					end = backfillTo
+				}
+
+				if err := h.backfill(ctx, fromBlock, end); err != nil {
 					s.err <- err
 					return
 				}
+
+				fromBlock = end + 1
+
+				if fromBlock > toBlock {
+					continue
+				}
+			}
+
+			if err := h.deliver(ctx, fromBlock, toBlock, bn); err != nil {
+				s.err <- err
+				return
 			}
 
-			fromBlock = currentBlock + 1
+			fromBlock = toBlock + 1
 		}
 	}(subscriberCtx)
 
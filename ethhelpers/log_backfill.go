@@ -0,0 +1,117 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	DefaultBackfillChunkSize    = 1000
+	DefaultBackfillMinChunkSize = 1
+	DefaultBackfillMaxChunkSize = 10000
+)
+
+// BackfillOptions configures the requests made when sending the logs of past
+// blocks.
+//
+// The chunk size is halved when a request fails with an error in
+// ErrorCategoryQueryTooLarge, and grows by half after each successful
+// request. Zero values are replaced by the defaults.
+type BackfillOptions struct {
+	// ChunkSize is the number of blocks in the first request.
+	ChunkSize uint64
+
+	// MinChunkSize is the lower limit of the chunk size, a request of this
+	// size failing with a query too large error is returned as an error.
+	MinChunkSize uint64
+
+	// MaxChunkSize is the upper limit of the chunk size.
+	MaxChunkSize uint64
+}
+
+func (opts BackfillOptions) withDefaults() (BackfillOptions, error) {
+	if opts.ChunkSize == 0 {
+		opts.ChunkSize = DefaultBackfillChunkSize
+	}
+	if opts.MinChunkSize == 0 {
+		opts.MinChunkSize = DefaultBackfillMinChunkSize
+	}
+	if opts.MaxChunkSize == 0 {
+		opts.MaxChunkSize = DefaultBackfillMaxChunkSize
+	}
+
+	switch {
+	case opts.MaxChunkSize < opts.MinChunkSize:
+		return BackfillOptions{}, fmt.Errorf("opts.MaxChunkSize must not be less than opts.MinChunkSize")
+	case opts.ChunkSize < opts.MinChunkSize || opts.ChunkSize > opts.MaxChunkSize:
+		return BackfillOptions{}, fmt.Errorf("opts.ChunkSize must be between opts.MinChunkSize and opts.MaxChunkSize")
+	}
+
+	return opts, nil
+}
+
+// logBackfiller requests the logs of past blocks in chunks that adapt to the
+// limits of the node.
+type logBackfiller struct {
+	opts      BackfillOptions
+	chunkSize uint64
+}
+
+func newLogBackfiller(opts BackfillOptions) *logBackfiller {
+	return &logBackfiller{
+		opts:      opts,
+		chunkSize: opts.ChunkSize,
+	}
+}
+
+// backfill calls filterLogs for each chunk of the blocks from fromBlock to
+// toBlock, in order, and then send with the logs returned and the last block
+// of the chunk.
+func (b *logBackfiller) backfill(ctx context.Context, fromBlock, toBlock uint64, filterLogs func(ctx context.Context, fromBlock, toBlock uint64) ([]types.Log, error), send func(logs []types.Log, toBlock uint64) error) error {
+	for fromBlock <= toBlock {
+		chunkEnd := toBlock
+		if toBlock-fromBlock >= b.chunkSize {
+			chunkEnd = fromBlock + b.chunkSize - 1
+		}
+
+		logs, err := filterLogs(ctx, fromBlock, chunkEnd)
+		if err != nil {
+			if ClassifyError(err) != ErrorCategoryQueryTooLarge || b.chunkSize == b.opts.MinChunkSize {
+				return fmt.Errorf("failed to backfill logs of blocks %d to %d: %w", fromBlock, chunkEnd, err)
+			}
+
+			b.chunkSize = b.chunkSize / 2
+			if b.chunkSize < b.opts.MinChunkSize {
+				b.chunkSize = b.opts.MinChunkSize
+			}
+
+			continue
+		}
+
+		if err := send(logs, chunkEnd); err != nil {
+			return err
+		}
+
+		b.chunkSize += b.chunkSize/2 + 1
+		if b.chunkSize > b.opts.MaxChunkSize {
+			b.chunkSize = b.opts.MaxChunkSize
+		}
+
+		fromBlock = chunkEnd + 1
+	}
+
+	return nil
+}
+
+// filterLogsInRange returns the logs of the query in the blocks from
+// fromBlock to toBlock.
+func filterLogsInRange(ctx context.Context, client FilterLogsReader, q ethereum.FilterQuery, fromBlock, toBlock uint64) ([]types.Log, error) {
+	q.FromBlock = new(big.Int).SetUint64(fromBlock)
+	q.ToBlock = new(big.Int).SetUint64(toBlock)
+
+	return client.FilterLogs(ctx, q)
+}
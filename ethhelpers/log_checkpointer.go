@@ -0,0 +1,201 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const DefaultCheckpointConfirmations = 12
+
+// CheckpointOptions configures where a log subscription saves the last block
+// whose logs have all been acknowledged.
+type CheckpointOptions struct {
+	Store CheckpointStore
+
+	// Key identifies the subscription in the store, and must be unique for
+	// each filter query sharing a store.
+	Key string
+
+	// Confirmations is the number of blocks below the last delivered block
+	// that the checkpoint is held back by, so that the logs of blocks that
+	// may still be replaced by a reorg are sent again after a restart, or
+	// DefaultCheckpointConfirmations if zero.
+	//
+	// Not used if HTTPSubscriberOptions.Confirmations is set, as only the
+	// logs of confirmed blocks are delivered.
+	Confirmations uint64
+}
+
+func (opts CheckpointOptions) validate() error {
+	switch {
+	case opts.Store == nil:
+		return fmt.Errorf("opts.Store must be set")
+	case opts.Key == "":
+		return fmt.Errorf("opts.Key must not be empty")
+	}
+
+	return nil
+}
+
+// CheckpointedSubscription is a log subscription that saves a checkpoint as
+// the logs are acknowledged.
+type CheckpointedSubscription interface {
+	ethereum.Subscription
+
+	// Ack acknowledges that log and all logs received before it have been
+	// processed, saving the last block whose logs have all been processed as
+	// the checkpoint.
+	//
+	// Removed logs must be acknowledged as well.
+	Ack(ctx context.Context, log types.Log) error
+}
+
+type checkpointedSubscription struct {
+	ethereum.Subscription
+	checkpointer *logCheckpointer
+}
+
+func (s *checkpointedSubscription) Ack(ctx context.Context, log types.Log) error {
+	return s.checkpointer.ack(ctx, log)
+}
+
+type logKey struct {
+	blockHash common.Hash
+	txHash    common.Hash
+	index     uint
+	removed   bool
+}
+
+func newLogKey(log types.Log) logKey {
+	return logKey{
+		blockHash: log.BlockHash,
+		txHash:    log.TxHash,
+		index:     log.Index,
+		removed:   log.Removed,
+	}
+}
+
+// pendingCheckpoint is a block whose logs have all been sent, which can be
+// saved once the first sent logs have been acknowledged.
+type pendingCheckpoint struct {
+	blockNumber uint64
+	sent        uint64
+}
+
+// logCheckpointer saves the last block whose logs have all been sent and
+// acknowledged, held back by depth blocks from the last delivered block.
+type logCheckpointer struct {
+	opts  CheckpointOptions
+	depth uint64
+
+	mu         sync.Mutex
+	started    bool
+	firstBlock uint64
+	unacked    []logKey
+	sent       uint64
+	acked      uint64
+	pending    []pendingCheckpoint
+}
+
+func newLogCheckpointer(opts CheckpointOptions, depth uint64) *logCheckpointer {
+	return &logCheckpointer{
+		opts:  opts,
+		depth: depth,
+	}
+}
+
+// logSent must be called before the log is sent, as it may be acknowledged as
+// soon as it has been received.
+func (c *logCheckpointer) logSent(log types.Log) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.unacked = append(c.unacked, newLogKey(log))
+	c.sent++
+}
+
+// delivered is called when the logs of all blocks from fromBlock up to
+// toBlock have been sent.
+func (c *logCheckpointer) delivered(ctx context.Context, fromBlock, toBlock uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.started {
+		c.started = true
+		c.firstBlock = fromBlock
+	}
+
+	// The checkpoint is never held back to before the first block of the
+	// subscription, as the logs of those blocks were not requested.
+	var blockNumber uint64
+
+	switch {
+	case toBlock >= c.firstBlock+c.depth:
+		blockNumber = toBlock - c.depth
+	case c.firstBlock != 0:
+		blockNumber = c.firstBlock - 1
+	default:
+		return nil
+	}
+
+	c.pending = append(c.pending, pendingCheckpoint{
+		blockNumber: blockNumber,
+		sent:        c.sent,
+	})
+
+	return c.save(ctx)
+}
+
+func (c *logCheckpointer) ack(ctx context.Context, log types.Log) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := newLogKey(log)
+	idx := -1
+
+	for i, k := range c.unacked {
+		if k == key {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return fmt.Errorf("log was not sent or has already been acknowledged")
+	}
+
+	c.unacked = c.unacked[idx+1:]
+	c.acked += uint64(idx + 1)
+
+	return c.save(ctx)
+}
+
+// save saves the last pending checkpoint whose logs have all been
+// acknowledged, if any.
+func (c *logCheckpointer) save(ctx context.Context) error {
+	idx := -1
+
+	for i, p := range c.pending {
+		if p.sent > c.acked {
+			break
+		}
+
+		idx = i
+	}
+	if idx == -1 {
+		return nil
+	}
+
+	blockNumber := c.pending[idx].blockNumber
+	c.pending = c.pending[idx+1:]
+
+	if err := c.opts.Store.SaveCheckpoint(ctx, c.opts.Key, blockNumber); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+package ethhelpers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxLogReorgAttempts is the number of times the logs of a range of blocks
+// are requested when the chain changes between requests.
+const maxLogReorgAttempts = 3
+
+type trackedBlock struct {
+	number     uint64
+	hash       common.Hash
+	parentHash common.Hash
+	logs       []types.Log
+}
+
+// logReorgTracker tracks the hashes and logs of the most recent blocks of a
+// log subscription, in order to retract the logs of blocks removed by a
+// reorg.
+type logReorgTracker struct {
+	client  HeaderByNumberReader
+	depth   uint64
+	metrics *SubscriptionMetrics
+
+	// blocks are the tracked blocks in ascending order, with the last being
+	// the parent of the next block requested.
+	blocks []trackedBlock
+
+	// removed are the logs of removed blocks that have not been returned yet.
+	removed []types.Log
+}
+
+func newLogReorgTracker(client HeaderByNumberReader, depth uint64, metrics *SubscriptionMetrics) *logReorgTracker {
+	return &logReorgTracker{
+		client:  client,
+		depth:   depth,
+		metrics: metrics,
+	}
+}
+
+// filterLogs returns the logs of the blocks from fromBlock to toBlock,
+// preceded by the logs of blocks removed by a reorg with Removed set.
+//
+// If a reorg is detected the logs are requested from the block following the
+// last tracked block that is still canonical.
+func (t *logReorgTracker) filterLogs(ctx context.Context, client FilterLogsReader, q ethereum.FilterQuery, fromBlock, toBlock uint64) ([]types.Log, error) {
+	for attempt := 0; attempt < maxLogReorgAttempts; attempt++ {
+		headers, err := t.headers(ctx, fromBlock, toBlock)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(t.blocks) != 0 && headers[fromBlock].ParentHash != t.blocks[len(t.blocks)-1].hash {
+			r, lastBlock, err := t.rewind(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			t.metrics.reorgDetected()
+
+			t.removed = append(r, t.removed...)
+			fromBlock = lastBlock + 1
+			continue
+		}
+		if !headersAreLinked(headers, fromBlock, toBlock) {
+			continue
+		}
+
+		logs, err := filterLogsInRange(ctx, client, q, fromBlock, toBlock)
+		if err != nil {
+			return nil, err
+		}
+
+		if !logsMatchHeaders(logs, headers) {
+			continue
+		}
+
+		t.track(headers, logs, fromBlock, toBlock)
+
+		logs = append(t.removed, logs...)
+		t.removed = nil
+
+		return logs, nil
+	}
+
+	return nil, fmt.Errorf("chain changed while requesting logs of blocks %d to %d", fromBlock, toBlock)
+}
+
+// headers returns the header of fromBlock and of the blocks that are
+// tracked after the range is delivered.
+func (t *logReorgTracker) headers(ctx context.Context, fromBlock, toBlock uint64) (map[uint64]*types.Header, error) {
+	headers := make(map[uint64]*types.Header)
+
+	for _, n := range append([]uint64{fromBlock}, t.trackedRange(fromBlock, toBlock)...) {
+		if _, ok := headers[n]; ok {
+			continue
+		}
+
+		header, err := t.client.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get header for block %d: %w", n, err)
+		}
+
+		headers[n] = header
+	}
+
+	return headers, nil
+}
+
+// trackedRange returns the numbers of the blocks from fromBlock to toBlock
+// that are within the tracking depth.
+func (t *logReorgTracker) trackedRange(fromBlock, toBlock uint64) []uint64 {
+	start := fromBlock
+	if toBlock-fromBlock >= t.depth {
+		start = toBlock - t.depth + 1
+	}
+
+	var numbers []uint64
+
+	for n := start; n <= toBlock; n++ {
+		numbers = append(numbers, n)
+	}
+
+	return numbers
+}
+
+// rewind removes the tracked blocks that are no longer canonical, and returns
+// their logs with Removed set and the number of the last tracked block.
+func (t *logReorgTracker) rewind(ctx context.Context) ([]types.Log, uint64, error) {
+	var removed []types.Log
+	var oldest trackedBlock
+	var count uint64
+
+	for len(t.blocks) != 0 {
+		block := t.blocks[len(t.blocks)-1]
+
+		header, err := t.client.HeaderByNumber(ctx, new(big.Int).SetUint64(block.number))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get header for block %d: %w", block.number, err)
+		}
+		if header.Hash() == block.hash {
+			return removed, block.number, nil
+		}
+
+		oldest = block
+		count++
+
+		logs := make([]types.Log, len(block.logs))
+
+		for idx, log := range block.logs {
+			log.Removed = true
+			logs[idx] = log
+		}
+
+		removed = append(logs, removed...)
+		t.blocks = t.blocks[:len(t.blocks)-1]
+	}
+
+	// All tracked blocks were removed, the reorg is within the tracking depth
+	// only if the parent of the oldest is still canonical.
+	if count < t.depth && oldest.number != 0 {
+		header, err := t.client.HeaderByNumber(ctx, new(big.Int).SetUint64(oldest.number-1))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get header for block %d: %w", oldest.number-1, err)
+		}
+		if header.Hash() == oldest.parentHash {
+			return removed, oldest.number - 1, nil
+		}
+	}
+
+	return nil, 0, fmt.Errorf("reorg is deeper than the tracking depth of %d blocks", t.depth)
+}
+
+func (t *logReorgTracker) track(headers map[uint64]*types.Header, logs []types.Log, fromBlock, toBlock uint64) {
+	for _, n := range t.trackedRange(fromBlock, toBlock) {
+		block := trackedBlock{
+			number:     n,
+			hash:       headers[n].Hash(),
+			parentHash: headers[n].ParentHash,
+		}
+
+		for _, log := range logs {
+			if log.BlockNumber == n {
+				block.logs = append(block.logs, log)
+			}
+		}
+
+		t.blocks = append(t.blocks, block)
+	}
+
+	if uint64(len(t.blocks)) > t.depth {
+		t.blocks = append([]trackedBlock(nil), t.blocks[uint64(len(t.blocks))-t.depth:]...)
+	}
+}
+
+// headersAreLinked returns false if the chain changed while the headers were
+// requested.
+func headersAreLinked(headers map[uint64]*types.Header, fromBlock, toBlock uint64) bool {
+	for n := fromBlock + 1; n <= toBlock; n++ {
+		parent, ok := headers[n-1]
+		if !ok {
+			continue
+		}
+		header, ok := headers[n]
+		if !ok {
+			continue
+		}
+
+		if header.ParentHash != parent.Hash() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// logsMatchHeaders returns false if the chain changed after the headers were
+// requested.
+func logsMatchHeaders(logs []types.Log, headers map[uint64]*types.Header) bool {
+	for _, log := range logs {
+		if header, ok := headers[log.BlockNumber]; ok && header.Hash() != log.BlockHash {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,72 @@
+package ethhelpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNotSupported is returned by calls to methods the client does not
+// support.
+var ErrNotSupported = errors.New("method not supported")
+
+var clientType = reflect.TypeOf((*Client)(nil)).Elem()
+
+type partialClient struct {
+	methods map[string]reflect.Value
+}
+
+// NewPartialClient creates a client that passes calls to the methods of impl
+// that have the same name and signature as the Client method, e.g. a
+// ReadClient, SubscribingClient or a test fake that implements only a few
+// methods.
+//
+// Calls to methods impl does not implement return an error wrapping
+// ErrNotSupported, except for Close which does nothing.
+//
+// Methods of impl that are not Client methods are ignored. An error is
+// returned if impl has a method with the name of a Client method but a
+// different signature.
+func NewPartialClient(impl interface{}) (Client, error) {
+	if impl == nil {
+		return nil, fmt.Errorf("impl must not be nil")
+	}
+
+	c := &partialClient{
+		methods: make(map[string]reflect.Value),
+	}
+
+	v := reflect.ValueOf(impl)
+
+	for idx := 0; idx < v.NumMethod(); idx++ {
+		name := v.Type().Method(idx).Name
+		fn := v.Method(idx)
+
+		method, ok := clientType.MethodByName(name)
+		if !ok {
+			continue
+		}
+		if fn.Type() != method.Type {
+			return nil, fmt.Errorf("impl method %s has type %s, expected %s", name, fn.Type(), method.Type)
+		}
+
+		c.methods[name] = fn
+	}
+
+	return NewClientWithDefaultHandler(c.handle), nil
+}
+
+func (c *partialClient) handle(ctx context.Context, caller ClientCaller) error {
+	fn, ok := c.methods[caller.Name()]
+	if !ok {
+		return fmt.Errorf("%s: %w", caller.Name(), ErrNotSupported)
+	}
+
+	results, err := callMethod(ctx, fn, caller.Args())
+	if err != nil {
+		return err
+	}
+
+	return caller.SetResults(results...)
+}
@@ -0,0 +1,144 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPartialClient struct {
+	blockNumber uint64
+	logs        []types.Log
+}
+
+func (c *testPartialClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.blockNumber, nil
+}
+
+func (c *testPartialClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if q.FromBlock == nil {
+		return nil, fmt.Errorf("missing from block")
+	}
+
+	return c.logs, nil
+}
+
+type testInvalidPartialClient struct{}
+
+type testFakePartialClient struct {
+	sync.Mutex
+}
+
+func (c *testInvalidPartialClient) BlockNumber(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (c *testFakePartialClient) String() string {
+	return "fake"
+}
+
+func (c *testFakePartialClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return 1234, nil
+}
+
+func TestCapabilityInterfaces(t *testing.T) {
+	clientType := reflect.TypeOf((*ethhelpers.Client)(nil)).Elem()
+
+	capabilities := []reflect.Type{
+		reflect.TypeOf((*ethhelpers.ReadClient)(nil)).Elem(),
+		reflect.TypeOf((*ethhelpers.SubscribingClient)(nil)).Elem(),
+		reflect.TypeOf((*ethhelpers.SendingClient)(nil)).Elem(),
+		reflect.TypeOf((*ethhelpers.AdminClient)(nil)).Elem(),
+	}
+
+	methods := map[string]string{}
+
+	for _, capability := range capabilities {
+		assert.True(t, clientType.Implements(capability), capability.Name())
+
+		for idx := 0; idx < capability.NumMethod(); idx++ {
+			name := capability.Method(idx).Name
+
+			if other, ok := methods[name]; ok {
+				t.Errorf("%s is in both %s and %s", name, other, capability.Name())
+			}
+
+			methods[name] = capability.Name()
+		}
+	}
+
+	for idx := 0; idx < clientType.NumMethod(); idx++ {
+		assert.Contains(t, methods, clientType.Method(idx).Name)
+	}
+}
+
+func TestNewPartialClient(t *testing.T) {
+	ctx := context.Background()
+	logs := []types.Log{{BlockNumber: 1234}}
+
+	client, err := ethhelpers.NewPartialClient(&testPartialClient{blockNumber: 1234, logs: logs})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	n, err := client.BlockNumber(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1234), n)
+
+	r, err := client.FilterLogs(ctx, ethereum.FilterQuery{FromBlock: big.NewInt(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, logs, r)
+
+	r, err = client.FilterLogs(ctx, ethereum.FilterQuery{})
+	assert.EqualError(t, err, "missing from block")
+	assert.Nil(t, r)
+
+	h, err := client.HeaderByNumber(ctx, nil)
+	assert.ErrorIs(t, err, ethhelpers.ErrNotSupported)
+	assert.EqualError(t, err, "HeaderByNumber: method not supported")
+	assert.Nil(t, h)
+
+	tx, isPending, err := client.TransactionByHash(ctx, common.Hash{})
+	assert.ErrorIs(t, err, ethhelpers.ErrNotSupported)
+	assert.Nil(t, tx)
+	assert.False(t, isPending)
+
+	err = client.SendTransaction(ctx, &types.Transaction{})
+	assert.ErrorIs(t, err, ethhelpers.ErrNotSupported)
+
+	sub, err := client.SubscribeNewHead(ctx, make(chan *types.Header))
+	assert.ErrorIs(t, err, ethhelpers.ErrNotSupported)
+	assert.Nil(t, sub)
+
+	client.Close()
+}
+
+func TestNewPartialClient_IgnoresOtherMethods(t *testing.T) {
+	client, err := ethhelpers.NewPartialClient(&testFakePartialClient{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	n, err := client.BlockNumber(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1234), n)
+}
+
+func TestNewPartialClient_InvalidImpl(t *testing.T) {
+	client, err := ethhelpers.NewPartialClient(nil)
+	assert.EqualError(t, err, "impl must not be nil")
+	assert.Nil(t, client)
+
+	client, err = ethhelpers.NewPartialClient(&testInvalidPartialClient{})
+	assert.EqualError(t, err, "impl method BlockNumber has type func(context.Context) (int64, error), expected func(context.Context) (uint64, error)")
+	assert.Nil(t, client)
+}
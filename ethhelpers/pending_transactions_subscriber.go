@@ -0,0 +1,180 @@
+package ethhelpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const DefaultPendingTransactionsPollInterval = 1 * time.Second
+
+type HTTPPendingTransactionsSubscriberOptions struct {
+	RPCClient *rpc.Client
+
+	// CreateContext returns a context that is used for the subscription, or
+	// context.Background() if nil.
+	CreateContext func() (context.Context, context.CancelFunc)
+
+	// PollInterval is the time between polls of the filter, independent of
+	// new blocks, or DefaultPendingTransactionsPollInterval if zero.
+	//
+	// The node removes filters that are not polled for a while, e.g. after
+	// 5 minutes for geth.
+	PollInterval time.Duration
+
+	// PollTick starts each poll of the filter, or a ticker with PollInterval
+	// is used if nil.
+	PollTick <-chan time.Time
+
+	// Hashes receives the hashes of new pending transactions, and must not
+	// be set together with Transactions.
+	Hashes chan<- common.Hash
+
+	// Transactions receives the new pending transactions, looked up with
+	// TransactionByHash, and must not be set together with Hashes.
+	//
+	// Transactions that are no longer found when looked up are skipped.
+	Transactions chan<- *types.Transaction
+
+	// Metrics records the health of the subscription, if not nil.
+	Metrics *SubscriptionMetrics
+}
+
+// SubscribePendingTransactionsWithHTTP installs a filter with
+// eth_newPendingTransactionFilter and sends the pending transactions returned
+// by eth_getFilterChanges, polled every opts.PollInterval.
+//
+// If the node has removed the filter it is installed again, and the
+// transactions that became pending in between are not sent.
+//
+// The context and Unsubscribe semantics are the same as for
+// SubscribeFilterLogsWithHTTP, and the filter is uninstalled when the
+// subscription ends.
+func SubscribePendingTransactionsWithHTTP(callerCtx context.Context, opts *HTTPPendingTransactionsSubscriberOptions) (ethereum.Subscription, error) {
+	if opts.RPCClient == nil {
+		return nil, fmt.Errorf("opts.RPCClient must be set")
+	}
+	if opts.PollInterval < 0 {
+		return nil, fmt.Errorf("opts.PollInterval must not be negative")
+	}
+	if (opts.Hashes == nil) == (opts.Transactions == nil) {
+		return nil, fmt.Errorf("either opts.Hashes or opts.Transactions must be set")
+	}
+
+	client := ethclient.NewClient(opts.RPCClient)
+	filter := &rpcFilter{rpcClient: opts.RPCClient}
+
+	if err := filter.install(callerCtx, "eth_newPendingTransactionFilter"); err != nil {
+		return nil, err
+	}
+
+	send := func(ctx context.Context, hash common.Hash) error {
+		if opts.Hashes != nil {
+			select {
+			case opts.Hashes <- hash:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		tx, _, err := client.TransactionByHash(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get transaction %s: %w", hash, err)
+		}
+
+		select {
+		case opts.Transactions <- tx:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	poll := func(ctx context.Context, tick time.Time) error {
+		var hashes []common.Hash
+
+		if err := filter.changes(ctx, &hashes); err != nil {
+			if ClassifyError(err) != ErrorCategoryFilterNotFound {
+				return fmt.Errorf("failed to get filter changes: %w", err)
+			}
+
+			if err := filter.install(ctx, "eth_newPendingTransactionFilter"); err != nil {
+				return fmt.Errorf("failed to reinstall expired filter: %w", err)
+			}
+
+			return nil
+		}
+
+		for _, hash := range hashes {
+			if err := send(ctx, hash); err != nil {
+				return err
+			}
+		}
+
+		opts.Metrics.tickDelivered(tick)
+
+		return nil
+	}
+
+	subscriberCtx, cancel := func() (context.Context, context.CancelFunc) {
+		if opts.CreateContext == nil {
+			return context.WithCancel(context.Background())
+		}
+
+		return opts.CreateContext()
+	}()
+
+	s := &httpSubscription{
+		cancel: cancel,
+		err:    make(chan error, 1),
+		done:   make(chan struct{}),
+	}
+
+	go func(ctx context.Context) {
+		defer close(s.done)
+		defer filter.uninstall()
+
+		tick := opts.PollTick
+
+		if tick == nil {
+			interval := opts.PollInterval
+			if interval == 0 {
+				interval = DefaultPendingTransactionsPollInterval
+			}
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			tick = ticker.C
+		}
+
+		for {
+			var t time.Time
+
+			select {
+			case t = <-tick:
+			case <-ctx.Done():
+				s.err <- ctx.Err()
+				return
+			}
+
+			if err := poll(ctx, t); err != nil {
+				s.err <- err
+				return
+			}
+		}
+	}(subscriberCtx)
+
+	return s, nil
+}
@@ -0,0 +1,313 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+)
+
+// testPendingEthService is a transaction pool with a pending transaction
+// filter, where each filter returns the transactions added after it was last
+// polled.
+type testPendingEthService struct {
+	mu      sync.Mutex
+	pool    []*types.Transaction
+	dropped map[common.Hash]bool
+	nextID  int
+	filters map[string]int
+}
+
+func newTestPendingEthService() *testPendingEthService {
+	return &testPendingEthService{
+		dropped: make(map[common.Hash]bool),
+		filters: make(map[string]int),
+	}
+}
+
+func (s *testPendingEthService) add(txs ...*types.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pool = append(s.pool, txs...)
+}
+
+func (s *testPendingEthService) drop(tx *types.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dropped[tx.Hash()] = true
+}
+
+func (s *testPendingEthService) expireFilters() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.filters = make(map[string]int)
+}
+
+func (s *testPendingEthService) filterCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.filters)
+}
+
+func (s *testPendingEthService) NewPendingTransactionFilter() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	id := hexutil.EncodeUint64(uint64(s.nextID))
+	s.filters[id] = len(s.pool)
+
+	return id
+}
+
+func (s *testPendingEthService) GetFilterChanges(id string) ([]common.Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last, ok := s.filters[id]
+	if !ok {
+		return nil, fmt.Errorf("filter not found")
+	}
+
+	hashes := []common.Hash{}
+	for _, tx := range s.pool[last:] {
+		hashes = append(hashes, tx.Hash())
+	}
+
+	s.filters[id] = len(s.pool)
+
+	return hashes, nil
+}
+
+func (s *testPendingEthService) UninstallFilter(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.filters[id]
+	delete(s.filters, id)
+
+	return ok
+}
+
+func (s *testPendingEthService) GetTransactionByHash(hash common.Hash) *types.Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dropped[hash] {
+		return nil
+	}
+
+	for _, tx := range s.pool {
+		if tx.Hash() == hash {
+			return tx
+		}
+	}
+
+	return nil
+}
+
+func TestSubscribePendingTransactionsWithHTTP(t *testing.T) {
+	txs := []*types.Transaction{
+		testSignedTransaction(1),
+		testSignedTransaction(2),
+		testSignedTransaction(3),
+		testSignedTransaction(4),
+	}
+
+	tests := []struct {
+		name   string
+		hashes bool
+		fn     func(t *testing.T, service *testPendingEthService, tick func(), expect func(...*types.Transaction))
+	}{
+		{
+			name:   "hashes are sent on each poll",
+			hashes: true,
+			fn: func(t *testing.T, service *testPendingEthService, tick func(), expect func(...*types.Transaction)) {
+				service.add(txs[0], txs[1])
+				tick()
+				expect(txs[0], txs[1])
+
+				service.add(txs[2])
+				tick()
+				expect(txs[2])
+			},
+		}, {
+			name: "transactions are looked up and dropped ones skipped",
+			fn: func(t *testing.T, service *testPendingEthService, tick func(), expect func(...*types.Transaction)) {
+				service.add(txs[0], txs[1], txs[2])
+				service.drop(txs[1])
+				tick()
+				expect(txs[0], txs[2])
+			},
+		}, {
+			name:   "expired filter is reinstalled",
+			hashes: true,
+			fn: func(t *testing.T, service *testPendingEthService, tick func(), expect func(...*types.Transaction)) {
+				service.add(txs[0])
+				tick()
+				expect(txs[0])
+
+				service.expireFilters()
+				service.add(txs[1])
+				tick()
+
+				// The next poll is started after the filter is reinstalled.
+				tick()
+
+				service.add(txs[2], txs[3])
+				tick()
+				expect(txs[2], txs[3])
+
+				assert.Equal(t, 1, service.filterCount())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			service := newTestPendingEthService()
+
+			server := rpc.NewServer()
+			defer server.Stop()
+
+			if err := server.RegisterName("eth", service); err != nil {
+				t.Fatal(err)
+			}
+
+			rpcClient := rpc.DialInProc(server)
+			defer rpcClient.Close()
+
+			pollTick := make(chan time.Time)
+
+			hashes := make(chan common.Hash, 16)
+			transactions := make(chan *types.Transaction, 16)
+
+			opts := &ethhelpers.HTTPPendingTransactionsSubscriberOptions{
+				RPCClient: rpcClient,
+				PollTick:  pollTick,
+			}
+			if test.hashes {
+				opts.Hashes = hashes
+			} else {
+				opts.Transactions = transactions
+			}
+
+			sub, err := ethhelpers.SubscribePendingTransactionsWithHTTP(ctx, opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, 1, service.filterCount())
+
+			tick := func() {
+				select {
+				case pollTick <- time.Now():
+				case <-ctx.Done():
+					t.Fatal("timed out")
+				}
+			}
+			expect := func(expected ...*types.Transaction) {
+				for _, tx := range expected {
+					select {
+					case hash := <-hashes:
+						assert.Equal(t, tx.Hash(), hash)
+					case r := <-transactions:
+						assert.Equal(t, tx.Hash(), r.Hash())
+					case err := <-sub.Err():
+						t.Fatalf("unexpected error: %v", err)
+					case <-ctx.Done():
+						t.Fatal("timed out")
+					}
+				}
+			}
+
+			test.fn(t, service, tick, expect)
+
+			sub.Unsubscribe()
+
+			assert.Empty(t, hashes)
+			assert.Empty(t, transactions)
+			assert.Equal(t, 0, service.filterCount())
+		})
+	}
+}
+
+func TestSubscribePendingTransactionsWithHTTP_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ethhelpers.HTTPPendingTransactionsSubscriberOptions
+		err  string
+	}{
+		{
+			name: "missing rpc client",
+			opts: ethhelpers.HTTPPendingTransactionsSubscriberOptions{Hashes: make(chan common.Hash)},
+			err:  "opts.RPCClient must be set",
+		}, {
+			name: "negative poll interval",
+			opts: ethhelpers.HTTPPendingTransactionsSubscriberOptions{RPCClient: &rpc.Client{}, PollInterval: -1, Hashes: make(chan common.Hash)},
+			err:  "opts.PollInterval must not be negative",
+		}, {
+			name: "missing channels",
+			opts: ethhelpers.HTTPPendingTransactionsSubscriberOptions{RPCClient: &rpc.Client{}},
+			err:  "either opts.Hashes or opts.Transactions must be set",
+		}, {
+			name: "both channels",
+			opts: ethhelpers.HTTPPendingTransactionsSubscriberOptions{
+				RPCClient:    &rpc.Client{},
+				Hashes:       make(chan common.Hash),
+				Transactions: make(chan *types.Transaction),
+			},
+			err: "either opts.Hashes or opts.Transactions must be set",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			sub, err := ethhelpers.SubscribePendingTransactionsWithHTTP(context.Background(), &test.opts)
+			assert.EqualError(t, err, test.err)
+			assert.Nil(t, sub)
+		})
+	}
+}
+
+func TestClientWithHTTPSubscriptions_SubscribePendingTransactions(t *testing.T) {
+	client, err := ethhelpers.NewClientWithHTTPSubscriptionsOptions(ethtesting.NewClientWithMock(), ethhelpers.HTTPSubscriptionsOptions{
+		CreateTicker: func(ctx context.Context, fromBlock uint64) (ethhelpers.BlockNumberTicker, error) {
+			return nil, fmt.Errorf("not called")
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	subscriber, ok := client.(ethhelpers.PendingTransactionsSubscriber)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	sub, err := subscriber.SubscribePendingTransactions(context.Background(), make(chan *types.Transaction))
+	assert.ErrorIs(t, err, ethhelpers.ErrNotSupported)
+	assert.Nil(t, sub)
+}
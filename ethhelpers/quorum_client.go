@@ -0,0 +1,258 @@
+package ethhelpers
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type QuorumClientOptions struct {
+	// Quorum is the number of clients that must return the same result, or a
+	// majority of the clients if zero.
+	Quorum int
+
+	// IsQuorum returns true if calls to the method are sent to all clients,
+	// or uses DefaultQuorumMethods if nil.
+	IsQuorum func(method string) bool
+}
+
+// QuorumResult holds the result returned by a single client.
+//
+// Values holds the return values of the call, not including the error.
+type QuorumResult struct {
+	Values []interface{}
+	Err    error
+}
+
+// QuorumError is returned when not enough clients returned the same result.
+//
+// Results holds the result of each client, in the same order as the clients
+// passed to NewQuorumClient.
+type QuorumError struct {
+	Method  string
+	Quorum  int
+	Results []QuorumResult
+}
+
+func (e *QuorumError) Error() string {
+	results := make([]string, len(e.Results))
+
+	for idx, r := range e.Results {
+		if r.Err != nil {
+			results[idx] = fmt.Sprintf("error: %v", r.Err)
+		} else {
+			results[idx] = fmt.Sprintf("%v", r.Values)
+		}
+	}
+
+	return fmt.Sprintf("quorum of %d not reached for %s: [%s]", e.Quorum, e.Method, strings.Join(results, ", "))
+}
+
+// DefaultQuorumMethods are the methods sent to all clients if
+// QuorumClientOptions.IsQuorum is nil.
+var DefaultQuorumMethods = map[string]bool{
+	"BlockByHash":        true,
+	"BlockByNumber":      true,
+	"HeaderByHash":       true,
+	"HeaderByNumber":     true,
+	"TransactionCount":   true,
+	"TransactionInBlock": true,
+	"BalanceAt":          true,
+	"StorageAt":          true,
+	"CodeAt":             true,
+	"NonceAt":            true,
+	"CallContract":       true,
+	"FilterLogs":         true,
+	"TransactionByHash":  true,
+	"TransactionReceipt": true,
+	"CallContractAtHash": true,
+	"ChainID":            true,
+}
+
+type quorumClient struct {
+	clients []Client
+	quorum  int
+	opts    QuorumClientOptions
+}
+
+// NewQuorumClient creates a client that sends reads of immutable or
+// block-specific state to all clients, and returns a result only if at least
+// Quorum clients returned the same result.
+//
+// Calls using the latest block may fail to reach quorum if the clients are
+// not in sync, and should therefore pass an explicit block number.
+//
+// Errors are only counted as the same result if they show the requested data
+// was not found or the call was reverted, other errors are treated as
+// disagreeing results.
+//
+// If the quorum is not reached a *QuorumError is returned.
+//
+// All other calls, including SendTransaction and subscriptions, are passed
+// only to the first client. Close closes all the clients.
+//
+// The returned client can be wrapped with e.g. NewClientWithRetry, or each
+// client can be wrapped separately to retry calls to individual providers.
+func NewQuorumClient(clients []Client, opts QuorumClientOptions) (Client, error) {
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("clients must not be empty")
+	}
+	for idx, client := range clients {
+		if client == nil {
+			return nil, fmt.Errorf("clients[%d] must not be nil", idx)
+		}
+	}
+
+	quorum := opts.Quorum
+	if quorum == 0 {
+		quorum = len(clients)/2 + 1
+	}
+	if quorum < 1 || quorum > len(clients) {
+		return nil, fmt.Errorf("opts.Quorum must be in the range [1, %d]", len(clients))
+	}
+
+	if opts.IsQuorum == nil {
+		opts.IsQuorum = func(method string) bool {
+			return DefaultQuorumMethods[method]
+		}
+	}
+
+	c := &quorumClient{
+		clients: clients,
+		quorum:  quorum,
+		opts:    opts,
+	}
+
+	return NewClientWithDefaultHandler(c.handle), nil
+}
+
+func (c *quorumClient) handle(ctx context.Context, caller ClientCaller) error {
+	if caller.Name() == "Close" {
+		for _, client := range c.clients {
+			_ = caller.Call(ctx, client)
+		}
+
+		return nil
+	}
+
+	if !c.opts.IsQuorum(caller.Name()) {
+		return caller.Call(ctx, c.clients[0])
+	}
+
+	values, err := c.call(ctx, caller.Name(), caller.Args())
+	if err != nil {
+		return err
+	}
+
+	return caller.SetResults(values...)
+}
+
+func (c *quorumClient) call(ctx context.Context, method string, args []interface{}) ([]interface{}, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type indexedResult struct {
+		index int
+		QuorumResult
+	}
+
+	resultChan := make(chan indexedResult, len(c.clients))
+
+	for idx, client := range c.clients {
+		go func(idx int, client Client) {
+			values, err := callClientMethod(ctx, client, method, args)
+			resultChan <- indexedResult{idx, QuorumResult{values, err}}
+		}(idx, client)
+	}
+
+	results := make([]QuorumResult, len(c.clients))
+	counts := make(map[string]int)
+
+	for range c.clients {
+		r := <-resultChan
+		results[r.index] = r.QuorumResult
+
+		key, ok := quorumKey(r.Values, r.Err)
+		if !ok {
+			continue
+		}
+
+		counts[key]++
+
+		if counts[key] >= c.quorum {
+			return r.Values, r.Err
+		}
+	}
+
+	return nil, &QuorumError{
+		Method:  method,
+		Quorum:  c.quorum,
+		Results: results,
+	}
+}
+
+// quorumKey returns a string that is equal for results that are considered
+// the same, or false if the result cannot be compared.
+func quorumKey(values []interface{}, err error) (string, bool) {
+	if err != nil {
+		switch category := ClassifyError(err); category {
+		case ErrorCategoryNotFound:
+			return "error:" + category.String(), true
+		case ErrorCategoryExecutionReverted:
+			return "error:" + category.String() + ":" + err.Error(), true
+		default:
+			return "", false
+		}
+	}
+
+	keys := make([]string, len(values))
+
+	for idx, value := range values {
+		key, ok := quorumValueKey(value)
+		if !ok {
+			return "", false
+		}
+
+		keys[idx] = key
+	}
+
+	return "values:" + strings.Join(keys, ","), true
+}
+
+func quorumValueKey(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case *types.Block:
+		if v == nil {
+			return "nil", true
+		}
+		return v.Hash().Hex(), true
+	case *types.Header:
+		if v == nil {
+			return "nil", true
+		}
+		return v.Hash().Hex(), true
+	case *types.Transaction:
+		if v == nil {
+			return "nil", true
+		}
+		return v.Hash().Hex(), true
+	case *big.Int:
+		if v == nil {
+			return "nil", true
+		}
+		return v.String(), true
+	case []byte:
+		return hex.EncodeToString(v), true
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(data), true
+	}
+}
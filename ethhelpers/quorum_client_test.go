@@ -0,0 +1,169 @@
+package ethhelpers_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"syscall"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQuorumClient(t *testing.T) {
+	type testArgs struct {
+		ctx    context.Context
+		client ethhelpers.Client
+		mocks  []*mock.Mock
+	}
+
+	account := common.HexToAddress("0x1234")
+	blockNumber := big.NewInt(100)
+	txHash := common.HexToHash("0x5678")
+
+	tests := []struct {
+		name string
+		opts ethhelpers.QuorumClientOptions
+		fn   func(*testing.T, testArgs)
+	}{
+		{
+			name: "BalanceAt returns result when all agree",
+			fn: func(t *testing.T, args testArgs) {
+				for _, m := range args.mocks {
+					m.On("BalanceAt", mock.Anything, account, blockNumber).Return(big.NewInt(1000), nil).Maybe()
+				}
+
+				balance, err := args.client.BalanceAt(args.ctx, account, blockNumber)
+				assert.NoError(t, err)
+				assert.Equal(t, big.NewInt(1000), balance)
+			},
+		}, {
+			name: "BalanceAt returns result when quorum agrees",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("BalanceAt", mock.Anything, account, blockNumber).Return(big.NewInt(1000), nil).Maybe()
+				args.mocks[1].On("BalanceAt", mock.Anything, account, blockNumber).Return(big.NewInt(9999), nil).Maybe()
+				args.mocks[2].On("BalanceAt", mock.Anything, account, blockNumber).Return(big.NewInt(1000), nil).Maybe()
+
+				balance, err := args.client.BalanceAt(args.ctx, account, blockNumber)
+				assert.NoError(t, err)
+				assert.Equal(t, big.NewInt(1000), balance)
+			},
+		}, {
+			name: "BalanceAt returns QuorumError when results disagree",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("BalanceAt", mock.Anything, account, blockNumber).Return(big.NewInt(1000), nil).Once()
+				args.mocks[1].On("BalanceAt", mock.Anything, account, blockNumber).Return(big.NewInt(2000), nil).Once()
+				args.mocks[2].On("BalanceAt", mock.Anything, account, blockNumber).Return(nil, syscall.ECONNRESET).Once()
+
+				balance, err := args.client.BalanceAt(args.ctx, account, blockNumber)
+				assert.Nil(t, balance)
+
+				var quorumErr *ethhelpers.QuorumError
+				if !assert.True(t, errors.As(err, &quorumErr)) {
+					return
+				}
+
+				assert.Equal(t, "BalanceAt", quorumErr.Method)
+				assert.Equal(t, 2, quorumErr.Quorum)
+				assert.Equal(t, []ethhelpers.QuorumResult{
+					{Values: []interface{}{big.NewInt(1000)}},
+					{Values: []interface{}{big.NewInt(2000)}},
+					{Values: []interface{}{(*big.Int)(nil)}, Err: syscall.ECONNRESET},
+				}, quorumErr.Results)
+			},
+		}, {
+			name: "TransactionReceipt returns not found when quorum agrees",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("TransactionReceipt", mock.Anything, txHash).Return(nil, ethereum.NotFound).Maybe()
+				args.mocks[1].On("TransactionReceipt", mock.Anything, txHash).Return(&types.Receipt{Status: 1}, nil).Maybe()
+				args.mocks[2].On("TransactionReceipt", mock.Anything, txHash).Return(nil, ethereum.NotFound).Maybe()
+
+				receipt, err := args.client.TransactionReceipt(args.ctx, txHash)
+				assert.ErrorIs(t, err, ethereum.NotFound)
+				assert.Nil(t, receipt)
+			},
+		}, {
+			name: "TransactionReceipt compares receipt content",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("TransactionReceipt", mock.Anything, txHash).Return(&types.Receipt{Status: 1, TxHash: txHash}, nil).Maybe()
+				args.mocks[1].On("TransactionReceipt", mock.Anything, txHash).Return(&types.Receipt{Status: 0, TxHash: txHash}, nil).Maybe()
+				args.mocks[2].On("TransactionReceipt", mock.Anything, txHash).Return(&types.Receipt{Status: 1, TxHash: txHash}, nil).Maybe()
+
+				receipt, err := args.client.TransactionReceipt(args.ctx, txHash)
+				assert.NoError(t, err)
+				if assert.NotNil(t, receipt) {
+					assert.Equal(t, uint64(1), receipt.Status)
+				}
+			},
+		}, {
+			name: "BlockNumber uses the first client",
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("BlockNumber", args.ctx).Return(uint64(123), nil).Once()
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(123), blockNumber)
+			},
+		}, {
+			name: "BlockNumber is sent to all clients if set by IsQuorum",
+			opts: ethhelpers.QuorumClientOptions{
+				IsQuorum: func(method string) bool {
+					return method == "BlockNumber"
+				},
+			},
+			fn: func(t *testing.T, args testArgs) {
+				args.mocks[0].On("BlockNumber", mock.Anything).Return(uint64(123), nil).Once()
+				args.mocks[1].On("BlockNumber", mock.Anything).Return(uint64(124), nil).Once()
+				args.mocks[2].On("BlockNumber", mock.Anything).Return(uint64(125), nil).Once()
+
+				blockNumber, err := args.client.BlockNumber(args.ctx)
+				assert.IsType(t, &ethhelpers.QuorumError{}, err)
+				assert.Equal(t, uint64(0), blockNumber)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			clients := []ethtesting.ClientWithMock{
+				ethtesting.NewClientWithMock(),
+				ethtesting.NewClientWithMock(),
+				ethtesting.NewClientWithMock(),
+			}
+
+			client, err := ethhelpers.NewQuorumClient([]ethhelpers.Client{clients[0], clients[1], clients[2]}, test.opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			test.fn(t, testArgs{
+				ctx,
+				client,
+				[]*mock.Mock{clients[0].Mock(), clients[1].Mock(), clients[2].Mock()},
+			})
+
+			for _, c := range clients {
+				c.Mock().AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestNewQuorumClient_InvalidArguments(t *testing.T) {
+	_, err := ethhelpers.NewQuorumClient(nil, ethhelpers.QuorumClientOptions{})
+	assert.Error(t, err)
+
+	_, err = ethhelpers.NewQuorumClient([]ethhelpers.Client{ethtesting.NewClientWithMock()}, ethhelpers.QuorumClientOptions{Quorum: 2})
+	assert.Error(t, err)
+}
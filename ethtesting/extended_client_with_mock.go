@@ -0,0 +1,38 @@
+package ethtesting
+
+import (
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers"
+	"github.com/stretchr/testify/mock"
+)
+
+type ExtendedClientWithMock interface {
+	ethhelpers.ExtendedClient
+	Mock() *mock.Mock
+	Test(t mock.TestingT)
+}
+
+type extendedClientWithMock struct {
+	*clientWithMock
+
+	client ethhelpers.ExtendedClient
+}
+
+// NewExtendedClientWithMock creates a new extended client with *testing.Mock.
+func NewExtendedClientWithMock() ExtendedClientWithMock {
+	return &extendedClientWithMock{
+		clientWithMock: &clientWithMock{},
+	}
+}
+
+// NewExtendedClientWithMockAndClient creates a new extended client with
+// *testing.Mock and an underlying client.
+//
+// Using PassthroughMockCall() as the assigned return value for mocked calls,
+// including those of the Client methods, will pass the call to the underlying
+// client.
+func NewExtendedClientWithMockAndClient(client ethhelpers.ExtendedClient) ExtendedClientWithMock {
+	return &extendedClientWithMock{
+		clientWithMock: &clientWithMock{client: client},
+		client:         client,
+	}
+}
@@ -0,0 +1,33 @@
+package ethtesting_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtendedClientWithMock_ClientMethods(t *testing.T) {
+	ctx := context.Background()
+
+	passthrough := ethtesting.NewExtendedClientWithMock()
+	passthrough.Test(t)
+
+	client := ethtesting.NewExtendedClientWithMockAndClient(passthrough)
+	client.Test(t)
+
+	client.Mock().On("BlockNumber", ctx).Return(uint64(1234), nil).Once()
+	r, err := client.BlockNumber(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1234), r)
+
+	client.Mock().On("BlockNumber", ctx).Return(ethtesting.PassthroughMockCall()).Once()
+	passthrough.Mock().On("BlockNumber", ctx).Return(uint64(1234), nil).Once()
+	r, err = client.BlockNumber(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1234), r)
+
+	client.Mock().AssertExpectations(t)
+	passthrough.Mock().AssertExpectations(t)
+}
@@ -36,7 +36,7 @@ func (c *simulatedClient) BlockNumber(ctx context.Context) (uint64, error) {
 }
 
 func (c *simulatedClient) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
-	return nil, fmt.Errorf("not implemented")
+	return nil, ethhelpers.ErrNotSupported
 }
 
 func (c *simulatedClient) ChainID(ctx context.Context) (*big.Int, error) {
@@ -53,29 +53,29 @@ func (c *simulatedClient) Close() {
 }
 
 func (c *simulatedClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
-	return nil, fmt.Errorf("not implemented")
+	return nil, ethhelpers.ErrNotSupported
 }
 
 func (c *simulatedClient) NetworkID(ctx context.Context) (*big.Int, error) {
-	return nil, fmt.Errorf("not implemented")
+	return nil, ethhelpers.ErrNotSupported
 }
 
 func (c *simulatedClient) PeerCount(ctx context.Context) (uint64, error) {
-	return 0, fmt.Errorf("not implemented")
+	return 0, ethhelpers.ErrNotSupported
 }
 
 func (c *simulatedClient) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
-	return nil, fmt.Errorf("not implemented")
+	return nil, ethhelpers.ErrNotSupported
 }
 
 func (c *simulatedClient) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
-	return nil, fmt.Errorf("not implemented")
+	return nil, ethhelpers.ErrNotSupported
 }
 
 func (c *simulatedClient) PendingTransactionCount(ctx context.Context) (uint, error) {
-	return 0, fmt.Errorf("not implemented")
+	return 0, ethhelpers.ErrNotSupported
 }
 
 func (c *simulatedClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
-	return nil, fmt.Errorf("not implemented")
+	return nil, ethhelpers.ErrNotSupported
 }
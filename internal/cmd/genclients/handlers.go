@@ -0,0 +1,319 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+func writeHandlerMethod(w *bytes.Buffer, m method) error {
+	var named, assigned, pointers []string
+
+	for _, v := range m.Values {
+		named = append(named, v.Name+" "+v.Type)
+		assigned = append(assigned, v.Name)
+		pointers = append(pointers, "&"+v.Name)
+	}
+	if m.HasError {
+		named = append(named, "e error")
+		assigned = append(assigned, "e")
+	}
+
+	results := ""
+	if len(named) != 0 {
+		results = " (" + strings.Join(named, ", ") + ")"
+	}
+
+	ctx := "ctx"
+	if !m.HasContext {
+		ctx = "context.Background()"
+	}
+
+	fmt.Fprintf(w, "\n// %s\n", m.Signature())
+	fmt.Fprintf(w, "func (c *clientWithHandlers) %s%s%s {\n", m.Name, m.Params, results)
+	fmt.Fprintf(w, "\tif err := c.handle(%s, ClientCaller{\n", ctx)
+	fmt.Fprintf(w, "\t\tname: %q,\n", m.Name)
+	fmt.Fprintf(w, "\t\targs: []interface{}{%s},\n", argNames(m))
+	fmt.Fprintf(w, "\t\tcall: func(ctx context.Context, client Client) error {\n")
+
+	if len(assigned) != 0 {
+		fmt.Fprintf(w, "\t\t\t%s = client.%s(%s)\n", strings.Join(assigned, ", "), m.Name, callArgs(m, "ctx"))
+	} else {
+		fmt.Fprintf(w, "\t\t\tclient.%s(%s)\n", m.Name, callArgs(m, "ctx"))
+	}
+	if m.HasError {
+		fmt.Fprintf(w, "\t\t\treturn e\n")
+	} else {
+		fmt.Fprintf(w, "\t\t\treturn nil\n")
+	}
+
+	fmt.Fprintf(w, "\t\t},\n")
+	fmt.Fprintf(w, "\t\tresults: func() []interface{} {\n")
+	fmt.Fprintf(w, "\t\t\treturn []interface{}{%s}\n", strings.Join(assigned[:len(m.Values)], ", "))
+	fmt.Fprintf(w, "\t\t},\n")
+	fmt.Fprintf(w, "\t\tsetResults: func(values []interface{}) error {\n")
+	fmt.Fprintf(w, "\t\t\treturn assignResults(%s)\n", strings.Join(append([]string{"values"}, pointers...), ", "))
+	fmt.Fprintf(w, "\t\t},\n")
+	fmt.Fprintf(w, "\t}); err != nil {\n")
+
+	if m.HasError {
+		fmt.Fprintf(w, "\t\treturn %s\n", zeroValues(m, "err"))
+	} else {
+		fmt.Fprintf(w, "\t\treturn\n")
+	}
+
+	fmt.Fprintf(w, "\t}\n")
+
+	if m.HasError {
+		fmt.Fprintf(w, "\n\te = nil\n\treturn\n")
+	}
+
+	fmt.Fprintf(w, "}\n")
+
+	return nil
+}
+
+func wrapHandlerTests(w *bytes.Buffer, methods []method, writeMethods func(*bytes.Buffer) error) error {
+	w.WriteString(`
+func TestClientWithDefaultHandler(t *testing.T) {
+	type testArgs struct {
+		ctx    context.Context
+		client ethhelpers.Client
+		mock   *mock.Mock
+	}
+
+	preCallError := fmt.Errorf("pre call error")
+	postCallError := fmt.Errorf("post call error")
+	callError := fmt.Errorf("call error")
+
+	tests := []struct {
+		name          string
+		call          func(*testing.T, testArgs)
+		preCallError  error
+		postCallError error
+	}{
+`)
+
+	if err := writeMethods(w); err != nil {
+		return err
+	}
+
+	w.WriteString(`	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			client := ethtesting.NewClientWithMock()
+
+			test.call(t, testArgs{
+				ctx,
+				ethhelpers.NewClientWithDefaultHandler(func(ctx context.Context, caller ethhelpers.ClientCaller) (e error) {
+					if test.preCallError != nil {
+						return test.preCallError
+					}
+
+					e = caller.Call(ctx, client)
+
+					if test.postCallError != nil {
+						return test.postCallError
+					}
+
+					return
+				}),
+				client.Mock(),
+			})
+
+			client.Mock().AssertExpectations(t)
+		})
+	}
+}
+`)
+
+	return nil
+}
+
+func writeHandlerTest(w *bytes.Buffer, m method) error {
+	tm := newTestMethod(m)
+
+	fmt.Fprintf(w, "\t\t// %s\n", m.Signature())
+
+	cases := []struct {
+		name         string
+		mocked       string
+		withExpected bool
+		expected     string
+		field        string
+	}{
+		{"returns success", tm.mockReturn(tm.expectedNames()...), true, "", ""},
+		{"call returns failure", tm.mockReturn(tm.typedZeros("callError")...), false, "callError", ""},
+		{"pre handler returns failure", "", false, "preCallError", "preCallError"},
+		{"post handler returns failure", tm.mockReturn(tm.expectedNames()...), true, "postCallError", "postCallError"},
+	}
+
+	for _, c := range cases {
+		// Methods without an error have no failure to return.
+		if !m.HasError && c.expected == "callError" {
+			continue
+		}
+
+		fmt.Fprintf(w, "\t\t{\n")
+		fmt.Fprintf(w, "\t\t\tname: %q,\n", m.Name+" "+c.name)
+		fmt.Fprintf(w, "\t\t\tcall: func(t *testing.T, args testArgs) {\n")
+
+		tm.writeDecls(w, "\t\t\t\t", c.withExpected)
+
+		if c.mocked != "" {
+			fmt.Fprintf(w, "\t\t\t\targs.mock.On(%s).Return(%s).Once()\n\n", tm.mockArgs("args.ctx"), c.mocked)
+		}
+
+		tm.writeCall(w, "\t\t\t\t", "args.client", "args.ctx", ":=")
+
+		if c.expected == "" {
+			tm.writeSuccessAsserts(w, "\t\t\t\t")
+		} else {
+			tm.writeFailureAsserts(w, "\t\t\t\t", c.expected)
+		}
+
+		fmt.Fprintf(w, "\t\t\t},\n")
+
+		if c.field != "" {
+			fmt.Fprintf(w, "\t\t\t%s: %s,\n", c.field, c.field)
+		}
+
+		fmt.Fprintf(w, "\t\t},\n")
+	}
+
+	return nil
+}
+
+// testMethod writes the statements shared by the generated tests of a method.
+type testMethod struct {
+	method
+}
+
+func newTestMethod(m method) testMethod {
+	return testMethod{m}
+}
+
+func (t testMethod) expectedNames() []string {
+	if len(t.Values) == 1 {
+		return []string{"expectedResult"}
+	}
+
+	var names []string
+
+	for idx := range t.Values {
+		names = append(names, fmt.Sprintf("expectedResult%d", idx))
+	}
+
+	return names
+}
+
+func (t testMethod) resultNames() []string {
+	if len(t.Values) == 1 {
+		return []string{"r"}
+	}
+
+	var names []string
+
+	for idx := range t.Values {
+		names = append(names, fmt.Sprintf("r%d", idx))
+	}
+
+	return names
+}
+
+// typedZeros returns the zero values of the results followed by err, as
+// passed to mock.Call.Return.
+func (t testMethod) typedZeros(err string) []string {
+	var values []string
+
+	for _, v := range t.Values {
+		values = append(values, typedZeroOf(v.Type))
+	}
+
+	return append(values, err)
+}
+
+// mockReturn returns the arguments to mock.Call.Return for the values,
+// followed by a nil error if values does not include the error.
+func (t testMethod) mockReturn(values ...string) string {
+	if !t.HasError {
+		return "nil"
+	}
+	if len(values) == len(t.Values) {
+		values = append(values, "nil")
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func (t testMethod) mockArgs(ctx string) string {
+	args := []string{fmt.Sprintf("%q", t.Name)}
+
+	if call := callArgs(t.method, ctx); call != "" {
+		args = append(args, call)
+	}
+
+	return strings.Join(args, ", ")
+}
+
+func (t testMethod) writeDecls(w *bytes.Buffer, indent string, withExpected bool) {
+	for _, arg := range t.Args {
+		fmt.Fprintf(w, "%s%s := %s\n", indent, arg.Name, testOf(arg.Type))
+	}
+
+	if withExpected {
+		for idx, name := range t.expectedNames() {
+			fmt.Fprintf(w, "%s%s := %s\n", indent, name, testOf(t.Values[idx].Type))
+		}
+	}
+
+	if len(t.Args) != 0 || (withExpected && len(t.Values) != 0) {
+		w.WriteString("\n")
+	}
+}
+
+func (t testMethod) writeCall(w *bytes.Buffer, indent string, client string, ctx string, assign string) {
+	results := t.resultNames()
+	if t.HasError {
+		results = append(results, "err")
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(w, "%s%s.%s(%s)\n", indent, client, t.Name, callArgs(t.method, ctx))
+		return
+	}
+
+	fmt.Fprintf(w, "%s%s %s %s.%s(%s)\n", indent, strings.Join(results, ", "), assign, client, t.Name, callArgs(t.method, ctx))
+}
+
+func (t testMethod) writeSuccessAsserts(w *bytes.Buffer, indent string) {
+	if !t.HasError {
+		return
+	}
+
+	fmt.Fprintf(w, "%sassert.NoError(t, err)\n", indent)
+
+	expected := t.expectedNames()
+
+	for idx, name := range t.resultNames() {
+		fmt.Fprintf(w, "%sassert.Equal(t, %s, %s)\n", indent, expected[idx], name)
+	}
+}
+
+func (t testMethod) writeFailureAsserts(w *bytes.Buffer, indent string, err string) {
+	if !t.HasError {
+		return
+	}
+
+	fmt.Fprintf(w, "%sassert.Same(t, %s, err)\n", indent, err)
+
+	for _, name := range t.resultNames() {
+		fmt.Fprintf(w, "%sassert.Zero(t, %s)\n", indent, name)
+	}
+}
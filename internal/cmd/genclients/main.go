@@ -0,0 +1,407 @@
+// Command genclients generates the methods of the handler and mock clients,
+// and their table tests, from the methods of an ethhelpers interface.
+//
+// It is run by go generate in the ethhelpers package:
+//
+//	go generate ./ethhelpers
+//
+// The handler client is only generated if -handlers is not empty. Methods of
+// embedded interfaces are skipped, and are generated from their own interface.
+//
+// Types used by methods of the interface must be added to knownTypes.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type options struct {
+	Source    string
+	Interface string
+	Handlers  string
+	Mock      string
+
+	// MockType is the name of the mock client struct, whose exported name is
+	// used by the generated tests.
+	MockType string
+}
+
+func main() {
+	var opts options
+
+	flag.StringVar(&opts.Source, "source", "clients.go", "file with the interface")
+	flag.StringVar(&opts.Interface, "interface", "Client", "name of the interface")
+	flag.StringVar(&opts.Handlers, "handlers", "client_with_handlers_gen.go", "output file of the handler client methods")
+	flag.StringVar(&opts.Mock, "mock", "../ethtesting/client_with_mock_gen.go", "output file of the mock client methods")
+	flag.StringVar(&opts.MockType, "mock-type", "clientWithMock", "name of the mock client struct")
+	flag.Parse()
+
+	files, err := generate(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "genclients: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, path := range sortedKeys(files) {
+		if err := ioutil.WriteFile(path, files[path], 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "genclients: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// generate returns the content of each generated file, by path.
+func generate(opts options) (map[string][]byte, error) {
+	src, err := ioutil.ReadFile(opts.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	methods, imports, err := parseInterface(opts.Source, src, opts.Interface)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+
+	outputs := []struct {
+		path    string
+		pkg     string
+		imports map[string]string
+		write   func(*bytes.Buffer, method) error
+		wrap    func(*bytes.Buffer, []method, func(*bytes.Buffer) error) error
+	}{
+		{opts.Handlers, "ethhelpers", imports, writeHandlerMethod, nil},
+		{opts.Mock, "ethtesting", withImports(imports, "github.com/rakshasa/go-ethereum-helpers/ethhelpers"), mockMethodWriter(opts.MockType), nil},
+		{testPath(opts.Handlers), "ethhelpers_test", withImports(imports, testImports...), writeHandlerTest, wrapHandlerTests},
+		{testPath(opts.Mock), "ethtesting_test", withImports(imports, testImports...), mockTestWriter(opts.MockType), mockTestsWrapper(opts.MockType)},
+	}
+
+	for _, output := range outputs {
+		if output.path == "" || output.path == testPath("") {
+			continue
+		}
+
+		var body bytes.Buffer
+
+		writeMethods := func(w *bytes.Buffer) error {
+			for _, m := range methods {
+				if err := output.write(w, m); err != nil {
+					return fmt.Errorf("%s: %w", m.Name, err)
+				}
+			}
+			return nil
+		}
+
+		if output.wrap != nil {
+			err = output.wrap(&body, methods, writeMethods)
+		} else {
+			err = writeMethods(&body)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := formatFile(output.pkg, output.imports, body.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", output.path, err)
+		}
+
+		files[output.path] = content
+	}
+
+	return files, nil
+}
+
+var testImports = []string{
+	"context",
+	"fmt",
+	"testing",
+	"github.com/ethereum/go-ethereum/rpc",
+	"github.com/rakshasa/go-ethereum-helpers/ethhelpers",
+	"github.com/rakshasa/go-ethereum-helpers/ethtesting",
+	"github.com/stretchr/testify/assert",
+	"github.com/stretchr/testify/mock",
+}
+
+func testPath(path string) string {
+	return strings.TrimSuffix(path, ".go") + "_test.go"
+}
+
+// withImports returns the imports with additional import paths, keyed by
+// package name.
+func withImports(imports map[string]string, paths ...string) map[string]string {
+	result := make(map[string]string, len(imports)+len(paths))
+
+	for name, path := range imports {
+		result[name] = path
+	}
+	for _, path := range paths {
+		result[path[strings.LastIndex(path, "/")+1:]] = path
+	}
+
+	return result
+}
+
+type param struct {
+	Name string
+	Type string
+}
+
+type method struct {
+	Name string
+
+	// Params and Results are the source text of the parameters and results,
+	// including parentheses.
+	Params  string
+	Results string
+
+	HasContext bool
+	HasError   bool
+
+	// Args are the parameters excluding the context.
+	Args []param
+
+	// Values are the results excluding the error, named as the handler client
+	// names them.
+	Values []param
+}
+
+func (m method) Signature() string {
+	if m.Results == "" {
+		return m.Name + m.Params
+	}
+
+	return m.Name + m.Params + " " + m.Results
+}
+
+// localType matches exported identifiers that are not qualified by a package.
+var localType = regexp.MustCompile(`(^|[^.\w])([A-Z]\w*)`)
+
+// parseInterface returns the methods of the interface and the imports of the
+// file, keyed by package name.
+//
+// Types declared in the package of the file are qualified by the package name,
+// as the generated files are in other packages except for the handler client.
+func parseInterface(filename string, src []byte, name string) ([]method, map[string]string, error) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imports := map[string]string{}
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if spec.Name != nil {
+			imports[spec.Name.Name] = path
+		} else {
+			imports[path[strings.LastIndex(path, "/")+1:]] = path
+		}
+	}
+
+	// The go-ethereum root package is named ethereum.
+	if path, ok := imports["go-ethereum"]; ok {
+		delete(imports, "go-ethereum")
+		imports["ethereum"] = path
+	}
+
+	obj := file.Scope.Lookup(name)
+	if obj == nil {
+		return nil, nil, fmt.Errorf("interface %s not found in %s", name, filename)
+	}
+
+	spec, ok := obj.Decl.(*ast.TypeSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s is not a type", name)
+	}
+
+	iface, ok := spec.Type.(*ast.InterfaceType)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s is not an interface", name)
+	}
+
+	qualify := func(text string) string {
+		return localType.ReplaceAllString(text, "${1}"+file.Name.Name+".${2}")
+	}
+
+	source := func(node ast.Node) string {
+		return qualify(string(src[fset.Position(node.Pos()).Offset:fset.Position(node.End()).Offset]))
+	}
+
+	var methods []method
+
+	for _, field := range iface.Methods.List {
+		if len(field.Names) == 0 {
+			continue
+		}
+
+		ft := field.Type.(*ast.FuncType)
+
+		m := method{
+			Name:   field.Names[0].Name,
+			Params: source(ft.Params),
+		}
+		if ft.Results != nil {
+			m.Results = source(ft.Results)
+		}
+
+		for idx, p := range expandFields(ft.Params, qualify) {
+			if p.Name == "" || p.Name == "_" {
+				return nil, nil, fmt.Errorf("%s: parameter %d must be named", m.Name, idx)
+			}
+
+			if idx == 0 && p.Type == "context.Context" {
+				m.HasContext = true
+				continue
+			}
+			if _, err := lookupType(p.Type); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", m.Name, err)
+			}
+
+			m.Args = append(m.Args, p)
+		}
+
+		results := expandFields(ft.Results, qualify)
+
+		if len(results) != 0 && results[len(results)-1].Type == "error" {
+			m.HasError = true
+			results = results[:len(results)-1]
+		}
+		if len(results) != 0 && !m.HasError {
+			return nil, nil, fmt.Errorf("%s: methods with results must return an error", m.Name)
+		}
+		if m.HasError && !m.HasContext {
+			return nil, nil, fmt.Errorf("%s: methods returning an error must have a context", m.Name)
+		}
+
+		for idx, r := range results {
+			if _, err := lookupType(r.Type); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", m.Name, err)
+			}
+
+			switch {
+			case idx == 0:
+				r.Name = "r"
+			case r.Name == "" || r.Name == "_":
+				r.Name = fmt.Sprintf("r%d", idx)
+			}
+
+			m.Values = append(m.Values, r)
+		}
+
+		methods = append(methods, m)
+	}
+
+	return methods, imports, nil
+}
+
+func expandFields(fields *ast.FieldList, qualify func(string) string) []param {
+	if fields == nil {
+		return nil
+	}
+
+	var params []param
+
+	for _, field := range fields.List {
+		typ := qualify(types.ExprString(field.Type))
+
+		if len(field.Names) == 0 {
+			params = append(params, param{Type: typ})
+			continue
+		}
+
+		for _, name := range field.Names {
+			params = append(params, param{Name: name.Name, Type: typ})
+		}
+	}
+
+	return params
+}
+
+// importedPackage matches qualified identifiers, excluding field selectors.
+var importedPackage = regexp.MustCompile(`(?:^|[^.\w])([a-z][a-z0-9]*)\.[A-Z]`)
+
+// formatFile adds the package clause and the imports used by body, and formats
+// the result.
+//
+// Types of pkg are unqualified in body.
+func formatFile(pkg string, imports map[string]string, body []byte) ([]byte, error) {
+	body = regexp.MustCompile(`(^|[^.\w])`+pkg+`\.`).ReplaceAll(body, []byte("${1}"))
+
+	used := map[string]bool{}
+
+	for _, match := range importedPackage.FindAllSubmatch(body, -1) {
+		if path, ok := imports[string(match[1])]; ok {
+			used[path] = true
+		}
+	}
+
+	var std, other []string
+
+	for path := range used {
+		if strings.Contains(path, ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+
+	sort.Strings(std)
+	sort.Strings(other)
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by genclients. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+
+	for _, path := range std {
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	if len(std) != 0 && len(other) != 0 {
+		buf.WriteString("\n")
+	}
+	for _, path := range other {
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+
+	buf.WriteString(")\n")
+	buf.Write(body)
+
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w\n%s", err, buf.Bytes())
+	}
+
+	return content, nil
+}
+
+func sortedKeys(files map[string][]byte) []string {
+	keys := make([]string, 0, len(files))
+
+	for key := range files {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "..", "ethhelpers")
+
+	tests := []struct {
+		opts  options
+		files int
+	}{
+		{
+			opts: options{
+				Source:    filepath.Join(dir, "clients.go"),
+				Interface: "Client",
+				Handlers:  filepath.Join(dir, "client_with_handlers_gen.go"),
+				Mock:      filepath.Join(dir, "..", "ethtesting", "client_with_mock_gen.go"),
+				MockType:  "clientWithMock",
+			},
+			files: 4,
+		}, {
+			opts: options{
+				Source:    filepath.Join(dir, "extended_client.go"),
+				Interface: "ExtendedClient",
+				Mock:      filepath.Join(dir, "..", "ethtesting", "extended_client_with_mock_gen.go"),
+				MockType:  "extendedClientWithMock",
+			},
+			files: 2,
+		},
+	}
+
+	for _, test := range tests {
+		files, err := generate(test.opts)
+		if !assert.NoError(t, err, test.opts.Interface) {
+			continue
+		}
+
+		assert.Len(t, files, test.files, test.opts.Interface)
+
+		for _, path := range sortedKeys(files) {
+			current, err := ioutil.ReadFile(path)
+			if !assert.NoError(t, err, path) {
+				continue
+			}
+
+			assert.Equal(t, string(files[path]), string(current), "%s is out of date, run go generate ./ethhelpers", path)
+		}
+	}
+}
+
+func TestGenerate_UnknownType(t *testing.T) {
+	src := []byte(`package test
+
+import "context"
+
+type Client interface {
+	Foo(ctx context.Context, value int32) error
+}
+`)
+
+	_, _, err := parseInterface("test.go", src, "Client")
+	assert.EqualError(t, err, "Foo: unknown type int32, add it to knownTypes")
+}
+
+func TestGenerate_LocalTypes(t *testing.T) {
+	src := []byte(`package test
+
+import "context"
+
+type Client interface {
+	Foo(ctx context.Context, value uint64) (*Result, error)
+}
+`)
+
+	knownTypes["*test.Result"] = typeInfo{"nil", "&test.Result{}"}
+	defer delete(knownTypes, "*test.Result")
+
+	methods, _, err := parseInterface("test.go", src, "Client")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "(*test.Result, error)", methods[0].Results)
+	assert.Equal(t, "*test.Result", methods[0].Values[0].Type)
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// exportedName returns the name with the first letter in upper case.
+func exportedName(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func mockMethodWriter(mockType string) func(*bytes.Buffer, method) error {
+	return func(w *bytes.Buffer, m method) error {
+		return writeMockMethod(w, mockType, m)
+	}
+}
+
+func writeMockMethod(w *bytes.Buffer, mockType string, m method) error {
+	failValues := "return"
+	if m.HasError {
+		failValues = "return " + zeroValues(m, "nil")
+	}
+
+	fmt.Fprintf(w, "\n// %s\n", m.Signature())
+
+	if m.Results == "" {
+		fmt.Fprintf(w, "func (c *%s) %s%s {\n", mockType, m.Name, m.Params)
+	} else {
+		fmt.Fprintf(w, "func (c *%s) %s%s %s {\n", mockType, m.Name, m.Params, m.Results)
+	}
+
+	fmt.Fprintf(w, "\tvalues := c.mock.MethodCalled(%s)\n\n", newTestMethod(m).mockArgs("ctx"))
+	fmt.Fprintf(w, "\tswitch v0 := values.Get(0).(type) {\n")
+
+	if m.HasContext {
+		fmt.Fprintf(w, "\tcase CanceledMockCallOption:\n")
+		fmt.Fprintf(w, "\t\tselect {\n")
+		fmt.Fprintf(w, "\t\tcase <-ctx.Done():\n")
+		fmt.Fprintf(w, "\t\t\treturn %s\n", zeroValues(m, "ctx.Err()"))
+		fmt.Fprintf(w, "\t\tdefault:\n")
+		fmt.Fprintf(w, "\t\t\tc.fail(\"mock call was canceled but context was not canceled\")\n")
+		fmt.Fprintf(w, "\t\t\t%s\n", failValues)
+		fmt.Fprintf(w, "\t\t}\n")
+	}
+
+	fmt.Fprintf(w, "\tcase PassthroughMockCallOption:\n")
+	fmt.Fprintf(w, "\t\tif c.client == nil {\n")
+	fmt.Fprintf(w, "\t\t\tc.fail(\"client is nil\")\n")
+	fmt.Fprintf(w, "\t\t\t%s\n", failValues)
+	fmt.Fprintf(w, "\t\t}\n")
+
+	if m.HasError {
+		fmt.Fprintf(w, "\t\treturn c.client.%s(%s)\n", m.Name, callArgs(m, "ctx"))
+	} else {
+		fmt.Fprintf(w, "\t\tc.client.%s(%s)\n", m.Name, callArgs(m, "ctx"))
+		fmt.Fprintf(w, "\t\treturn\n")
+	}
+
+	switch {
+	case len(m.Values) != 0:
+		fmt.Fprintf(w, "\tcase %s:\n", m.Values[0].Type)
+		writeMockValues(w, m, "\t\t", []string{"v0"}, failValues)
+		fmt.Fprintf(w, "\tcase nil:\n")
+		writeMockValues(w, m, "\t\t", []string{zeroOf(m.Values[0].Type)}, failValues)
+	case m.HasError:
+		fmt.Fprintf(w, "\tcase error:\n")
+		fmt.Fprintf(w, "\t\treturn v0\n")
+		fmt.Fprintf(w, "\tcase nil:\n")
+		fmt.Fprintf(w, "\t\treturn nil\n")
+	default:
+		fmt.Fprintf(w, "\tcase nil:\n")
+		fmt.Fprintf(w, "\t\treturn\n")
+	}
+
+	fmt.Fprintf(w, "\tdefault:\n")
+	fmt.Fprintf(w, "\t\tc.fail(\"unexpected mock return type: %%T\", v0)\n")
+	fmt.Fprintf(w, "\t\t%s\n", failValues)
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "}\n")
+
+	return nil
+}
+
+// writeMockValues writes the statements returning the mocked values, checking
+// the type of each value after those already in values.
+func writeMockValues(w *bytes.Buffer, m method, indent string, values []string, failValues string) {
+	idx := len(values)
+
+	if idx == len(m.Values) {
+		fmt.Fprintf(w, "%sreturn %s, values.Error(%d)\n", indent, strings.Join(values, ", "), idx)
+		return
+	}
+
+	fmt.Fprintf(w, "%sif v%d, ok := values.Get(%d).(%s); ok {\n", indent, idx, idx, m.Values[idx].Type)
+	writeMockValues(w, m, indent+"\t", append(values, fmt.Sprintf("v%d", idx)), failValues)
+	fmt.Fprintf(w, "%s} else {\n", indent)
+	fmt.Fprintf(w, "%s\tc.fail(\"unexpected mock return type: %%T\", values.Get(%d))\n", indent, idx)
+	fmt.Fprintf(w, "%s\t%s\n", indent, failValues)
+	fmt.Fprintf(w, "%s}\n", indent)
+}
+
+func mockTestsWrapper(mockType string) func(*bytes.Buffer, []method, func(*bytes.Buffer) error) error {
+	return func(w *bytes.Buffer, methods []method, writeMethods func(*bytes.Buffer) error) error {
+		return wrapMockTests(w, exportedName(mockType), writeMethods)
+	}
+}
+
+func wrapMockTests(w *bytes.Buffer, name string, writeMethods func(*bytes.Buffer) error) error {
+	fmt.Fprintf(w, `
+func Test%s_Methods(t *testing.T) {
+	callError := fmt.Errorf("call error")
+
+	tests := []struct {
+		name string
+		call func(t *testing.T, ctx context.Context, client ethtesting.%s, passthrough ethtesting.%s)
+	}{
+`, name, name, name)
+
+	if err := writeMethods(w); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, `	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			passthrough := ethtesting.New%s()
+			passthrough.Test(t)
+
+			client := ethtesting.New%sAndClient(passthrough)
+			client.Test(t)
+
+			test.call(t, context.Background(), client, passthrough)
+
+			client.Mock().AssertExpectations(t)
+			passthrough.Mock().AssertExpectations(t)
+		})
+	}
+}
+`, name, name)
+
+	return nil
+}
+
+func mockTestWriter(mockType string) func(*bytes.Buffer, method) error {
+	return func(w *bytes.Buffer, m method) error {
+		return writeMockTest(w, exportedName(mockType), m)
+	}
+}
+
+func writeMockTest(w *bytes.Buffer, name string, m method) error {
+	tm := newTestMethod(m)
+	indent := "\t\t\t\t"
+
+	fmt.Fprintf(w, "\t\t// %s\n", m.Signature())
+	fmt.Fprintf(w, "\t\t{\n")
+	fmt.Fprintf(w, "\t\t\tname: %q,\n", m.Name)
+	fmt.Fprintf(w, "\t\t\tcall: func(t *testing.T, ctx context.Context, client ethtesting.%s, passthrough ethtesting.%s) {\n", name, name)
+
+	tm.writeDecls(w, indent, true)
+
+	assign := ":="
+
+	writeCase := func(mocked string, fn func()) {
+		fmt.Fprintf(w, "%sclient.Mock().On(%s).Return(%s).Once()\n", indent, tm.mockArgs("ctx"), mocked)
+		fn()
+		tm.writeCall(w, indent, "client", "ctx", assign)
+		assign = "="
+	}
+
+	writeCase(tm.mockReturn(tm.expectedNames()...), func() {})
+	tm.writeSuccessAsserts(w, indent)
+
+	if m.HasError {
+		w.WriteString("\n")
+		writeCase(tm.mockReturn(tm.typedZeros("callError")...), func() {})
+		tm.writeFailureAsserts(w, indent, "callError")
+	}
+
+	w.WriteString("\n")
+	writeCase("ethtesting.PassthroughMockCall()", func() {
+		fmt.Fprintf(w, "%spassthrough.Mock().On(%s).Return(%s).Once()\n", indent, tm.mockArgs("ctx"), tm.mockReturn(tm.expectedNames()...))
+	})
+	tm.writeSuccessAsserts(w, indent)
+
+	if m.HasContext {
+		w.WriteString("\n")
+		fmt.Fprintf(w, "%scanceledCtx, cancel := context.WithCancel(ctx)\n", indent)
+		fmt.Fprintf(w, "%scancel()\n", indent)
+		fmt.Fprintf(w, "%sclient.Mock().On(%s).Return(ethtesting.CanceledMockCall()).Once()\n", indent, tm.mockArgs("canceledCtx"))
+		tm.writeCall(w, indent, "client", "canceledCtx", assign)
+		tm.writeFailureAsserts(w, indent, "context.Canceled")
+	}
+
+	fmt.Fprintf(w, "\t\t\t},\n")
+	fmt.Fprintf(w, "\t\t},\n")
+
+	return nil
+}
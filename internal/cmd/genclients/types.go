@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type typeInfo struct {
+	// Zero is the zero value of the type in a return statement.
+	Zero string
+
+	// Test is a non-zero value of the type used by generated tests.
+	Test string
+}
+
+// knownTypes holds the types of the parameters and results of the interface
+// methods, as formatted by types.ExprString and qualified by parseInterface.
+var knownTypes = map[string]typeInfo{
+	"[]byte":                       {"nil", `[]byte{0x01}`},
+	"[]float64":                    {"nil", `[]float64{0.5}`},
+	"[]string":                     {"nil", `[]string{"0x1234"}`},
+	"[]types.Log":                  {"nil", `[]types.Log{{BlockNumber: 1234}}`},
+	"[]*types.Receipt":             {"nil", `[]*types.Receipt{{Status: 1}}`},
+	"*big.Int":                     {"nil", `big.NewInt(1234)`},
+	"*ethereum.FeeHistory":         {"nil", `&ethereum.FeeHistory{OldestBlock: big.NewInt(1234)}`},
+	"*ethereum.SyncProgress":       {"nil", `&ethereum.SyncProgress{CurrentBlock: 1234}`},
+	"*ethhelpers.AccessListResult": {"nil", `&ethhelpers.AccessListResult{GasUsed: 1234}`},
+	"*ethhelpers.AccountResult":    {"nil", `&ethhelpers.AccountResult{Address: common.HexToAddress("0x1234")}`},
+	"*ethhelpers.CallFrame":        {"nil", `&ethhelpers.CallFrame{Type: "CALL"}`},
+	"*ethhelpers.TxPoolContent":    {"nil", `&ethhelpers.TxPoolContent{Pending: map[common.Address]map[string]*types.Transaction{}}`},
+	"*ethhelpers.TxPoolStatus":     {"nil", `&ethhelpers.TxPoolStatus{Pending: 1234}`},
+	"*types.Block":                 {"nil", `types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1234)})`},
+	"*types.Header":                {"nil", `&types.Header{Number: big.NewInt(1234)}`},
+	"*types.Receipt":               {"nil", `&types.Receipt{Status: 1}`},
+	"*types.Transaction":           {"nil", `types.NewTx(&types.LegacyTx{Nonce: 1234})`},
+	"bool":                         {"false", `true`},
+	"chan<- *types.Header":         {"nil", `make(chan<- *types.Header)`},
+	"chan<- types.Log":             {"nil", `make(chan<- types.Log)`},
+	"common.Address":               {"common.Address{}", `common.HexToAddress("0x1234")`},
+	"common.Hash":                  {"common.Hash{}", `common.HexToHash("0x1234")`},
+	"ethereum.CallMsg":             {"ethereum.CallMsg{}", `ethereum.CallMsg{Data: []byte{0x01}}`},
+	"ethereum.FilterQuery":         {"ethereum.FilterQuery{}", `ethereum.FilterQuery{FromBlock: big.NewInt(1234)}`},
+	"ethereum.Subscription":        {"nil", `&rpc.ClientSubscription{}`},
+	"map[common.Address]ethhelpers.OverrideAccount": {"nil", `map[common.Address]ethhelpers.OverrideAccount{common.HexToAddress("0x1234"): {Code: []byte{0x01}}}`},
+	"rpc.BlockNumberOrHash":                         {"rpc.BlockNumberOrHash{}", `rpc.BlockNumberOrHashWithNumber(1234)`},
+	"string":                                        {`""`, `"1234"`},
+	"uint":                                          {"0", `uint(1234)`},
+	"uint64":                                        {"0", `uint64(1234)`},
+}
+
+func lookupType(typ string) (typeInfo, error) {
+	info, ok := knownTypes[typ]
+	if !ok {
+		return typeInfo{}, fmt.Errorf("unknown type %s, add it to knownTypes", typ)
+	}
+
+	return info, nil
+}
+
+func zeroOf(typ string) string {
+	info, _ := lookupType(typ)
+	return info.Zero
+}
+
+func testOf(typ string) string {
+	info, _ := lookupType(typ)
+	return info.Test
+}
+
+// typedZeroOf returns the zero value of the type, converted to the type if
+// passed as an interface{}.
+func typedZeroOf(typ string) string {
+	if zero := zeroOf(typ); zero != "0" {
+		return zero
+	}
+
+	return typ + "(0)"
+}
+
+// zeroValues returns the zero values of the method results, followed by err if
+// the method returns an error.
+func zeroValues(m method, err string) string {
+	var values []string
+
+	for _, v := range m.Values {
+		values = append(values, zeroOf(v.Type))
+	}
+	if m.HasError {
+		values = append(values, err)
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// callArgs returns the arguments of a call to the method, starting with ctx if
+// the method has a context.
+func callArgs(m method, ctx string) string {
+	var args []string
+
+	if m.HasContext {
+		args = append(args, ctx)
+	}
+	for _, arg := range m.Args {
+		args = append(args, arg.Name)
+	}
+
+	return strings.Join(args, ", ")
+}
+
+func argNames(m method) string {
+	var names []string
+
+	for _, arg := range m.Args {
+		names = append(names, arg.Name)
+	}
+
+	return strings.Join(names, ", ")
+}